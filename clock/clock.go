@@ -0,0 +1,45 @@
+// Package clock implements source of current time and timers, real for service and virtual for tests.
+package clock
+
+import "time"
+
+// Clock source of current time and timers.
+type Clock interface {
+	Now() time.Time
+	// NewTimer return timer sending current time on its channel after d.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer single event timer of Clock.
+type Timer interface {
+	C() <-chan time.Time
+	// Stop timer, return false if timer already fired or stopped.
+	Stop() bool
+}
+
+// System return clock of real time.
+func System() Clock {
+	return systemClock{}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	timer *time.Timer
+}
+
+func (o systemTimer) C() <-chan time.Time {
+	return o.timer.C
+}
+
+func (o systemTimer) Stop() bool {
+	return o.timer.Stop()
+}
@@ -0,0 +1,105 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fake virtual clock for tests: time is changed only by Advance and Set, timers fire when time reach their deadline.
+type Fake struct {
+	mux    sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFake return virtual clock started at now.
+func NewFake(now time.Time) *Fake {
+	o := &Fake{now: now}
+	o.cond = sync.NewCond(&o.mux)
+
+	return o
+}
+
+// Now return virtual time.
+func (o *Fake) Now() time.Time {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	return o.now
+}
+
+// NewTimer return timer fired by Advance or Set, timer with d <= 0 fires immediately.
+func (o *Fake) NewTimer(d time.Duration) Timer {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	timer := &fakeTimer{clock: o, deadline: o.now.Add(d), c: make(chan time.Time, 1)}
+
+	if d <= 0 {
+		timer.c <- o.now
+		return timer
+	}
+
+	o.timers = append(o.timers, timer)
+	o.cond.Broadcast()
+
+	return timer
+}
+
+// Advance virtual time by d and fire timers with deadline not after new time.
+func (o *Fake) Advance(d time.Duration) {
+	o.Set(o.Now().Add(d))
+}
+
+// Set virtual time and fire timers with deadline not after it.
+func (o *Fake) Set(now time.Time) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	o.now = now
+
+	sort.Slice(o.timers, func(i, j int) bool {
+		return o.timers[i].deadline.Before(o.timers[j].deadline)
+	})
+
+	for len(o.timers) > 0 && !o.timers[0].deadline.After(now) {
+		o.timers[0].c <- now
+		o.timers = o.timers[1:]
+	}
+}
+
+// BlockUntil wait until count timers are waiting for fire.
+func (o *Fake) BlockUntil(count int) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	for len(o.timers) < count {
+		o.cond.Wait()
+	}
+}
+
+type fakeTimer struct {
+	clock    *Fake
+	deadline time.Time
+	c        chan time.Time
+}
+
+func (o *fakeTimer) C() <-chan time.Time {
+	return o.c
+}
+
+func (o *fakeTimer) Stop() bool {
+	o.clock.mux.Lock()
+	defer o.clock.mux.Unlock()
+
+	for i, timer := range o.clock.timers {
+		if timer == o {
+			o.clock.timers = append(o.clock.timers[:i], o.clock.timers[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,51 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/clock"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+
+	late := fake.NewTimer(2 * time.Hour)
+	early := fake.NewTimer(time.Hour)
+	stopped := fake.NewTimer(time.Minute)
+
+	if !stopped.Stop() || stopped.Stop() {
+		t.Error("Stop() of waiting timer = false or Stop() of stopped timer = true")
+	}
+
+	fake.BlockUntil(2)
+	fake.Advance(90 * time.Minute)
+
+	select {
+	case now := <-early.C():
+		if !now.Equal(start.Add(90 * time.Minute)) {
+			t.Errorf("timer fired at %v", now)
+		}
+	default:
+		t.Error("timer not fired after its deadline")
+	}
+
+	select {
+	case <-late.C():
+		t.Error("timer fired before its deadline")
+	case <-stopped.C():
+		t.Error("stopped timer fired")
+	default:
+	}
+
+	if expired := fake.NewTimer(0); len(expired.C()) != 1 {
+		t.Error("timer without duration not fired immediately")
+	}
+
+	fake.Set(start.Add(3 * time.Hour))
+
+	if len(late.C()) != 1 || !fake.Now().Equal(start.Add(3*time.Hour)) {
+		t.Errorf("Set() = %v, timer not fired", fake.Now())
+	}
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/service"
+	"github.com/Volkov-Stanislav/silences-sheduler/storages"
+	"github.com/namsral/flag"
+	"go.uber.org/zap"
+)
+
+// runNext execute "next" command: print upcoming silence windows of file, section in shedules_dir,
+// or all files in shedules_dir. Return exit code.
+func runNext(config map[string]string, args []string) int {
+	var (
+		count   int
+		horizon string
+		asJSON  bool
+	)
+
+	flags := flag.NewFlagSet("next", flag.ContinueOnError)
+	flags.IntVar(&count, "count", 10, "count of windows")
+	flags.StringVar(&horizon, "horizon", "720h", "duration from now")
+	flags.BoolVar(&asJSON, "json", false, "print windows as JSON")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: silences-sheduler [flags] next [-count N] [-horizon 720h] [-json] [file or section]")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	d, err := models.ParseDuration(horizon)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	load := func(fileName string) ([]models.SheduleSection, error) {
+		return storages.ReadFile(fileName, config, logger)
+	}
+
+	files, err := nextFiles(config["shedules_dir"], flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	from := time.Now()
+
+	var result []service.Occurrence
+
+	for _, file := range files {
+		occurrences, err := service.PreviewFile(load, file, from, from.Add(time.Duration(d)), count)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v: %v\n", file, err)
+			return 1
+		}
+
+		result = append(result, occurrences...)
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(result); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		return 0
+	}
+
+	fmt.Println("ID;Start - End Zone;Section;Shedule;Comment;Matchers")
+
+	for _, occurrence := range result {
+		fmt.Println(occurrence)
+	}
+
+	return 0
+}
+
+// nextFiles return files for "next" command: file name, section (file name) in dir, or all shedule files in dir.
+func nextFiles(dir, name string) ([]string, error) {
+	if name != "" {
+		if _, err := os.Stat(name); err == nil {
+			return []string{name}, nil
+		}
+
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("file or section %v not found", name)
+		}
+
+		return []string{path}, nil
+	}
+
+	var result []string
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		switch filepath.Ext(path) {
+		case ".yaml", ".csv", ".ics":
+			if !info.IsDir() {
+				result = append(result, path)
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// runValidate execute "validate" command: check files, or all files in shedules_dir, and overlaps of their shedules.
+// Return exit code 1 if there are errors, overlaps are warnings.
+func runValidate(config map[string]string, args []string) int {
+	var horizon string
+
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.StringVar(&horizon, "horizon", service.OverlapHorizon.String(), "horizon of overlap check from now")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: silences-sheduler [flags] validate [-horizon 672h] [file or section...]")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	d, err := models.ParseDuration(horizon)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	names := flags.Args()
+	if len(names) == 0 {
+		names = []string{""}
+	}
+
+	var files []string
+
+	for _, name := range names {
+		list, err := nextFiles(config["shedules_dir"], name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		files = append(files, list...)
+	}
+
+	var (
+		sections []*models.SheduleSection
+		failed   bool
+	)
+
+	calendars := models.NewCalendarSet()
+
+	for _, file := range files {
+		list, err := storages.ReadFile(file, config, logger)
+		if err != nil {
+			fmt.Printf("error: %v: %v\n", file, err)
+			failed = true
+
+			continue
+		}
+
+		for key := range list {
+			section := &list[key]
+
+			for _, err := range section.LoadCalendars(time.Now()) {
+				fmt.Printf("error: %v: %v\n", file, err)
+				failed = true
+			}
+
+			calendars.Set(file+"|"+section.GetToken(), section.Calendars)
+			sections = append(sections, section)
+		}
+	}
+
+	policy, err := models.NewPolicy(config["policy_file"])
+	if err != nil {
+		fmt.Printf("error: %v\n", err)
+		failed = true
+	}
+
+	env := &models.Environment{Calendars: calendars, Policy: policy}
+
+	for _, section := range sections {
+		for _, err := range section.Validate(env) {
+			fmt.Printf("error: %v: %v\n", section.GetFilePath(), err)
+			failed = true
+		}
+	}
+
+	now := time.Now()
+
+	for _, overlap := range models.FindOverlaps(env, sections, now, now.Add(time.Duration(d))) {
+		fmt.Printf("warning: %v\n", overlap)
+	}
+
+	if failed {
+		return 1
+	}
+
+	fmt.Printf("%v files, %v sections are valid\n", len(files), len(sections))
+
+	return 0
+}
+
+// runAction execute "skip" and "run-now" commands: request action for shedule ID from running service.
+func runAction(config map[string]string, action string, args []string) int {
+	var (
+		count int
+		addr  string
+	)
+
+	flags := flag.NewFlagSet(action, flag.ContinueOnError)
+	flags.StringVar(&addr, "addr", "http://localhost:"+config["statistic_port"], "URL of statistic port of running service")
+
+	if action == "skip" {
+		flags.IntVar(&count, "count", 1, "count of next runs to skip, 0 cancel skip")
+	}
+
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: silences-sheduler [flags] %v [-addr URL] [-count N] <shedule ID>\n", action)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	query := url.Values{"id": {flags.Arg(0)}}
+	path := "/run"
+
+	if action == "skip" {
+		path = "/skip"
+		query.Set("count", strconv.Itoa(count))
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(addr, "/")+path+"?"+query.Encode(), nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	auth, err := service.NewControlAuth(config["control_token_file"], false)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if auth.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+auth.Token)
+	}
+
+	if current, err := user.Current(); err == nil {
+		req.Header.Set("X-Forwarded-User", current.Username)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		fmt.Fprintf(os.Stderr, "%v: %s", resp.Status, body)
+		return 1
+	}
+
+	fmt.Printf("%v %v: %v %s\n", action, flags.Arg(0), resp.Status, body)
+
+	return 0
+}
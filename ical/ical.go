@@ -0,0 +1,383 @@
+// Package ical implements parsing of iCalendar (RFC 5545) files, enough for maintenance calendars.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Property content line of iCalendar: NAME;PARAM=VALUE:value.
+type Property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Event VEVENT component of calendar.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string    // DESCRIPTION.
+	Start       time.Time // DTSTART.
+	End         time.Time // DTEND, DTSTART+DURATION, or DTSTART for events without end.
+	AllDay      bool      // DTSTART is date without time.
+	Rule        *Rule     // RRULE, nil for not recurring event.
+	ExDates     []time.Time
+	Props       []Property
+	rrule       string
+	duration    time.Duration
+}
+
+// Recurring check if event has recurrence rule.
+func (o Event) Recurring() bool {
+	return o.Rule != nil
+}
+
+// Duration return duration of every occurrence of event.
+func (o Event) Duration() time.Duration {
+	return o.End.Sub(o.Start)
+}
+
+// Next return start of first occurrence of event after t, zero time if there is no such occurrence.
+func (o Event) Next(t time.Time) time.Time {
+	if o.Rule == nil {
+		if o.Start.After(t) {
+			return o.Start
+		}
+
+		return time.Time{}
+	}
+
+	var next time.Time
+
+	o.Rule.occurrences(o.Start, func(occurrence time.Time) bool {
+		if !occurrence.After(t) || o.excluded(occurrence) {
+			return true
+		}
+
+		next = occurrence
+
+		return false
+	})
+
+	return next
+}
+
+// Prop return value of first property with name, "" if there is no such property.
+func (o Event) Prop(name string) string {
+	for _, prop := range o.Props {
+		if prop.Name == name {
+			return prop.Value
+		}
+	}
+
+	return ""
+}
+
+// PropValues return values of all properties with name.
+func (o Event) PropValues(name string) []string {
+	var result []string
+
+	for _, prop := range o.Props {
+		if prop.Name == name {
+			result = append(result, prop.Value)
+		}
+	}
+
+	return result
+}
+
+func (o Event) excluded(occurrence time.Time) bool {
+	for _, exdate := range o.ExDates {
+		if exdate.Equal(occurrence) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// finish set end and recurrence rule of event after all properties parsed.
+func (o *Event) finish() error {
+	if o.Start.IsZero() {
+		return fmt.Errorf("event %q without DTSTART", o.UID)
+	}
+
+	if o.End.IsZero() {
+		switch {
+		case o.duration > 0:
+			o.End = o.Start.Add(o.duration)
+		case o.AllDay:
+			o.End = o.Start.AddDate(0, 0, 1)
+		default:
+			o.End = o.Start
+		}
+	}
+
+	if o.rrule == "" {
+		return nil
+	}
+
+	rule, err := ParseRule(o.rrule, o.Start.Location())
+	if err != nil {
+		return fmt.Errorf("event %q: %w", o.UID, err)
+	}
+
+	o.Rule = rule
+
+	return nil
+}
+
+// Dates return all dates (in location of event start) touched by first occurrence of event,
+// end of event is exclusive.
+func (o Event) Dates() []string {
+	return dates(o.Start, o.End)
+}
+
+// DatesBetween return all dates touched by occurrences of event started from from till to.
+func (o Event) DatesBetween(from, to time.Time) []string {
+	var result []string
+
+	for _, start := range o.Occurrences(from, to) {
+		result = append(result, dates(start, start.Add(o.Duration()))...)
+	}
+
+	return result
+}
+
+// Occurrences return starts of occurrences of event from from (inclusive) till to (exclusive).
+func (o Event) Occurrences(from, to time.Time) []time.Time {
+	var result []time.Time
+
+	for next := o.Next(from.Add(-time.Nanosecond)); !next.IsZero() && next.Before(to); next = o.Next(next) {
+		result = append(result, next)
+	}
+
+	return result
+}
+
+func dates(start, end time.Time) []string {
+	var result []string
+
+	if end.After(start) {
+		end = end.Add(-time.Nanosecond)
+	}
+
+	for day := dateOnly(start); !day.After(end); day = day.AddDate(0, 0, 1) {
+		result = append(result, day.Format("2006-01-02"))
+	}
+
+	return result
+}
+
+// Parse read VEVENTs from calendar.
+func Parse(r io.Reader) ([]Event, error) {
+	var (
+		events  []Event
+		current *Event
+	)
+
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for num, line := range lines {
+		prop, err := parseProperty(line)
+		if err != nil {
+			return nil, fmt.Errorf("content line %v: %w", num+1, err)
+		}
+
+		switch {
+		case prop.Name == "BEGIN" && prop.Value == "VEVENT":
+			current = &Event{}
+		case prop.Name == "END" && prop.Value == "VEVENT" && current != nil:
+			if err := current.finish(); err != nil {
+				return nil, err
+			}
+
+			events = append(events, *current)
+			current = nil
+		case current != nil:
+			if err := current.set(prop); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// set property of event.
+func (o *Event) set(prop Property) error {
+	var err error
+
+	o.Props = append(o.Props, prop)
+
+	switch prop.Name {
+	case "UID":
+		o.UID = prop.Value
+	case "SUMMARY":
+		o.Summary = prop.Value
+	case "DESCRIPTION":
+		o.Description = prop.Value
+	case "DTSTART":
+		o.Start, o.AllDay, err = ParseDateTime(prop)
+	case "DTEND":
+		o.End, _, err = ParseDateTime(prop)
+	case "DURATION":
+		o.duration, err = ParseDuration(prop.Value)
+	case "RRULE":
+		o.rrule = prop.Value
+	case "EXDATE":
+		for _, value := range strings.Split(prop.Value, ",") {
+			exdate := prop
+			exdate.Value = value
+
+			t, _, err := ParseDateTime(exdate)
+			if err != nil {
+				return err
+			}
+
+			o.ExDates = append(o.ExDates, t)
+		}
+	}
+
+	return err
+}
+
+// ParseDuration parse DURATION value, like "PT3H30M", "P1D", "P1W".
+func ParseDuration(value string) (time.Duration, error) {
+	var (
+		result time.Duration
+		num    int
+		inTime bool
+	)
+
+	if !strings.HasPrefix(value, "P") && !strings.HasPrefix(value, "+P") {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	units := map[rune]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour, 'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+
+	for _, c := range strings.TrimPrefix(strings.TrimPrefix(value, "+"), "P") {
+		switch {
+		case c >= '0' && c <= '9':
+			num = num*10 + int(c-'0')
+		case c == 'T':
+			inTime = true
+		case c == 'M' && !inTime:
+			return 0, fmt.Errorf("invalid duration %q: months not supported", value)
+		default:
+			unit, ok := units[c]
+			if !ok {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+
+			result += time.Duration(num) * unit
+			num = 0
+		}
+	}
+
+	return result, nil
+}
+
+// ParseDateTime parse DATE or DATE-TIME value of property, with TZID parameter. Return true for DATE value.
+func ParseDateTime(prop Property) (time.Time, bool, error) {
+	loc := time.Local
+
+	if tzid, ok := prop.Params["TZID"]; ok {
+		zone, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%v: unknown TZID %q", prop.Name, tzid)
+		}
+
+		loc = zone
+	}
+
+	value := prop.Value
+
+	switch {
+	case len(value) == len("20060102"):
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	case strings.HasSuffix(value, "Z"):
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	default:
+		t, err := time.ParseInLocation("20060102T150405", value, loc)
+		return t, false, err
+	}
+}
+
+// unfold read content lines, joining folded lines.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, scanner.Err()
+}
+
+// parseProperty parse content line.
+func parseProperty(line string) (Property, error) {
+	prop := Property{Params: map[string]string{}}
+
+	colon := valueIndex(line)
+	if colon < 0 {
+		return prop, fmt.Errorf("invalid content line, want NAME[;PARAM=VALUE]:value")
+	}
+
+	prop.Value = unescape(line[colon+1:])
+	parts := strings.Split(line[:colon], ";")
+	prop.Name = strings.ToUpper(parts[0])
+
+	for _, param := range parts[1:] {
+		if eq := strings.Index(param, "="); eq > 0 {
+			prop.Params[strings.ToUpper(param[:eq])] = strings.Trim(param[eq+1:], `"`)
+		}
+	}
+
+	return prop, nil
+}
+
+// valueIndex return index of colon separating value, skipping quoted parameter values.
+func valueIndex(line string) int {
+	quoted := false
+
+	for i, c := range line {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case c == ':' && !quoted:
+			return i
+		}
+	}
+
+	return -1
+}
+
+func unescape(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,65 @@
+package ical_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/ical"
+)
+
+const holidays = `BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VEVENT
+UID:ny-2027
+SUMMARY:New Year
+  holidays
+DTSTART;VALUE=DATE:20270101
+DTEND;VALUE=DATE:20270104
+END:VEVENT
+BEGIN:VEVENT
+UID:freeze
+SUMMARY:Change freeze\, release
+DTSTART;TZID=Europe/Moscow:20261230T180000
+DTEND;TZID=Europe/Moscow:20261231T120000
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestParse(t *testing.T) {
+	events, err := ical.Parse(strings.NewReader(holidays))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Parse() events = %v, want 2", len(events))
+	}
+
+	if events[0].Summary != "New Year holidays" || !events[0].AllDay {
+		t.Errorf("Parse() event = %#v", events[0])
+	}
+
+	if got := events[0].Dates(); !reflect.DeepEqual(got, []string{"2027-01-01", "2027-01-02", "2027-01-03"}) {
+		t.Errorf("Dates() = %v", got)
+	}
+
+	if events[1].Summary != "Change freeze, release" || events[1].Start.Location().String() != "Europe/Moscow" {
+		t.Errorf("Parse() event = %#v", events[1])
+	}
+
+	if got := events[1].Dates(); !reflect.DeepEqual(got, []string{"2026-12-30", "2026-12-31"}) {
+		t.Errorf("Dates() = %v", got)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	if _, err := ical.Parse(strings.NewReader("BEGIN:VEVENT\nSUMMARY:no start\nEND:VEVENT\n")); err == nil {
+		t.Error("Parse() error = nil, want error for event without DTSTART")
+	}
+
+	_, err := ical.Parse(strings.NewReader("BEGIN:VEVENT\ns3cr3t-token\nEND:VEVENT\n"))
+	if err == nil || strings.Contains(err.Error(), "s3cr3t") || !strings.Contains(err.Error(), "content line 2") {
+		t.Errorf("Parse() error = %v, want error with number of content line and without its content", err)
+	}
+}
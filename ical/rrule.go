@@ -0,0 +1,302 @@
+package ical
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxPeriods limit of iterated recurrence periods, for rules without matching occurrences.
+const maxPeriods = 100000
+
+var byDayNames = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// weekdayNum BYDAY value: weekday with optional number, 0 mean every weekday in period.
+type weekdayNum struct {
+	n       int
+	weekday time.Weekday
+}
+
+// Rule recurrence rule (RRULE) with frequencies DAILY, WEEKLY, MONTHLY and YEARLY.
+type Rule struct {
+	Freq       string
+	Interval   int
+	Count      int
+	Until      time.Time
+	byDay      []weekdayNum
+	byMonthDay []int
+	byMonth    []time.Month
+}
+
+// ParseRule parse RRULE value, UNTIL without zone use loc.
+func ParseRule(value string, loc *time.Location) (*Rule, error) {
+	rule := Rule{Interval: 1}
+
+	for _, part := range strings.Split(value, ";") {
+		eq := strings.Index(part, "=")
+		if eq < 0 {
+			return nil, fmt.Errorf("invalid RRULE part %q", part)
+		}
+
+		key, val := strings.ToUpper(part[:eq]), part[eq+1:]
+
+		var err error
+
+		switch key {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(val)
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+			if err == nil && rule.Interval < 1 {
+				err = fmt.Errorf("interval must be positive")
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+		case "UNTIL":
+			var date bool
+
+			rule.Until, date, err = ParseDateTime(Property{Name: key, Value: val, Params: map[string]string{"TZID": loc.String()}})
+			if date {
+				// Date in UNTIL include whole day.
+				rule.Until = rule.Until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+		case "BYDAY":
+			rule.byDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			rule.byMonthDay, err = parseInts(val, 31)
+		case "BYMONTH":
+			var months []int
+
+			months, err = parseInts(val, 12)
+			for _, month := range months {
+				if month < 0 {
+					err = fmt.Errorf("invalid month %v", month)
+				}
+
+				rule.byMonth = append(rule.byMonth, time.Month(month))
+			}
+		case "WKST":
+		default:
+			err = fmt.Errorf("unsupported part")
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("RRULE %v=%v: %w", key, val, err)
+		}
+	}
+
+	switch rule.Freq {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+	default:
+		return nil, fmt.Errorf("unsupported RRULE FREQ %q", rule.Freq)
+	}
+
+	return &rule, nil
+}
+
+// occurrences call fn for every occurrence of rule starting at start (start itself included),
+// until fn return false.
+func (o *Rule) occurrences(start time.Time, fn func(time.Time) bool) {
+	count := 0
+
+	for period := 0; period < maxPeriods; period++ {
+		candidates := o.expand(start, period*o.Interval)
+
+		for _, candidate := range candidates {
+			if candidate.Before(start) {
+				continue
+			}
+
+			if !o.Until.IsZero() && candidate.After(o.Until) {
+				return
+			}
+
+			count++
+			if o.Count > 0 && count > o.Count {
+				return
+			}
+
+			if !fn(candidate) {
+				return
+			}
+		}
+	}
+}
+
+// expand return sorted occurrences of period with offset from start period.
+func (o *Rule) expand(start time.Time, offset int) []time.Time {
+	var days []time.Time
+
+	loc := start.Location()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), 0, loc)
+	}
+
+	switch o.Freq {
+	case "DAILY":
+		days = append(days, at(start.Year(), start.Month(), start.Day()+offset))
+	case "WEEKLY":
+		// Weeks start on monday.
+		monday := start.Day() - (int(start.Weekday())+6)%7 + offset*7
+
+		if len(o.byDay) == 0 {
+			days = append(days, at(start.Year(), start.Month(), start.Day()+offset*7))
+		}
+
+		for _, wd := range o.byDay {
+			days = append(days, at(start.Year(), start.Month(), monday+(int(wd.weekday)+6)%7))
+		}
+	case "MONTHLY":
+		first := at(start.Year(), start.Month()+time.Month(offset), 1)
+		days = o.expandMonth(first, start.Day(), at)
+	case "YEARLY":
+		months := o.byMonth
+		if len(months) == 0 {
+			months = []time.Month{start.Month()}
+		}
+
+		for _, month := range months {
+			days = append(days, o.expandMonth(at(start.Year()+offset, month, 1), start.Day(), at)...)
+		}
+	}
+
+	result := days[:0]
+
+	for _, day := range days {
+		if o.match(day) {
+			result = append(result, day)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+
+	return result
+}
+
+// expandMonth return days of month of first by BYMONTHDAY and BYDAY, or day of start.
+func (o *Rule) expandMonth(first time.Time, startDay int, at func(int, time.Month, int) time.Time) []time.Time {
+	var days []time.Time
+
+	last := first.AddDate(0, 1, -1).Day()
+
+	for _, day := range o.byMonthDay {
+		if day < 0 {
+			day = last + day + 1
+		}
+
+		if day >= 1 && day <= last {
+			days = append(days, at(first.Year(), first.Month(), day))
+		}
+	}
+
+	if len(o.byMonthDay) > 0 {
+		return days
+	}
+
+	for _, wd := range o.byDay {
+		var matched []int
+
+		for day := 1; day <= last; day++ {
+			if at(first.Year(), first.Month(), day).Weekday() == wd.weekday {
+				matched = append(matched, day)
+			}
+		}
+
+		switch {
+		case wd.n == 0:
+			for _, day := range matched {
+				days = append(days, at(first.Year(), first.Month(), day))
+			}
+		case wd.n > 0 && wd.n <= len(matched):
+			days = append(days, at(first.Year(), first.Month(), matched[wd.n-1]))
+		case wd.n < 0 && -wd.n <= len(matched):
+			days = append(days, at(first.Year(), first.Month(), matched[len(matched)+wd.n]))
+		}
+	}
+
+	if len(o.byDay) == 0 && startDay <= last {
+		days = append(days, at(first.Year(), first.Month(), startDay))
+	}
+
+	return days
+}
+
+// match check BYMONTH, and BYDAY for daily rule.
+func (o *Rule) match(day time.Time) bool {
+	if len(o.byMonth) > 0 && !containsMonth(o.byMonth, day.Month()) {
+		return false
+	}
+
+	if o.Freq != "DAILY" || len(o.byDay) == 0 {
+		return true
+	}
+
+	for _, wd := range o.byDay {
+		if wd.weekday == day.Weekday() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsMonth(months []time.Month, month time.Month) bool {
+	for _, m := range months {
+		if m == month {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseByDay(value string) ([]weekdayNum, error) {
+	var result []weekdayNum
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToUpper(strings.TrimSpace(item))
+		if len(item) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", item)
+		}
+
+		weekday, ok := byDayNames[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", item)
+		}
+
+		wd := weekdayNum{weekday: weekday}
+
+		if num := item[:len(item)-2]; num != "" {
+			n, err := strconv.Atoi(num)
+			if err != nil || n == 0 || n > 5 || n < -5 {
+				return nil, fmt.Errorf("invalid weekday number %q", item)
+			}
+
+			wd.n = n
+		}
+
+		result = append(result, wd)
+	}
+
+	return result, nil
+}
+
+func parseInts(value string, limit int) ([]int, error) {
+	var result []int
+
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil || n == 0 || n > limit || n < -limit {
+			return nil, fmt.Errorf("invalid value %q", item)
+		}
+
+		result = append(result, n)
+	}
+
+	return result, nil
+}
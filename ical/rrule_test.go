@@ -0,0 +1,87 @@
+package ical_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/ical"
+)
+
+func TestEvent_Occurrences(t *testing.T) {
+	tests := []struct {
+		name  string
+		event string
+		want  []string
+	}{
+		{
+			name:  "Weekly by days with exdate",
+			event: "DTSTART;TZID=Europe/Moscow:20261102T220000\nDURATION:PT8H\nRRULE:FREQ=WEEKLY;BYDAY=MO,WE\nEXDATE;TZID=Europe/Moscow:20261104T220000",
+			want:  []string{"2026-11-02 22:00", "2026-11-09 22:00", "2026-11-11 22:00"},
+		},
+		{
+			name:  "Monthly second tuesday with count",
+			event: "DTSTART;TZID=Europe/Moscow:20261110T020000\nDTEND;TZID=Europe/Moscow:20261110T050000\nRRULE:FREQ=MONTHLY;BYDAY=2TU;COUNT=2",
+			want:  []string{"2026-11-10 02:00", "2026-12-08 02:00"},
+		},
+		{
+			name:  "Daily with interval and until",
+			event: "DTSTART:20261101T010000Z\nRRULE:FREQ=DAILY;INTERVAL=2;UNTIL=20261105T235959Z",
+			want:  []string{"2026-11-01 01:00", "2026-11-03 01:00", "2026-11-05 01:00"},
+		},
+		{
+			name:  "Monthly last day",
+			event: "DTSTART:20261130T230000Z\nRRULE:FREQ=MONTHLY;BYMONTHDAY=-1",
+			want:  []string{"2026-11-30 23:00", "2026-12-31 23:00", "2027-01-31 23:00"},
+		},
+		{
+			name:  "Yearly by month",
+			event: "DTSTART;VALUE=DATE:20270101\nRRULE:FREQ=YEARLY;BYMONTH=1,5;BYMONTHDAY=1",
+			want:  []string{"2027-01-01 00:00", "2027-05-01 00:00", "2028-01-01 00:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := ical.Parse(strings.NewReader("BEGIN:VEVENT\n" + tt.event + "\nEND:VEVENT\n"))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			from := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+			occurrences := events[0].Occurrences(from, from.AddDate(1, 3, 0))
+
+			var got []string
+			for _, occurrence := range occurrences {
+				got = append(got, occurrence.Format("2006-01-02 15:04"))
+			}
+
+			if len(got) > len(tt.want) {
+				got = got[:len(tt.want)]
+			}
+
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Occurrences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"PT3H30M": 3*time.Hour + 30*time.Minute,
+		"P1D":     24 * time.Hour,
+		"P1W":     7 * 24 * time.Hour,
+		"P1DT2H":  26 * time.Hour,
+	}
+
+	for value, want := range tests {
+		if got, err := ical.ParseDuration(value); err != nil || got != want {
+			t.Errorf("ParseDuration(%q) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+
+	if _, err := ical.ParseDuration("P1M"); err == nil {
+		t.Error("ParseDuration(P1M) error = nil, want error")
+	}
+}
@@ -0,0 +1,81 @@
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+)
+
+// lineLength max length of content line in octets, longer lines are folded.
+const lineLength = 75
+
+// utcFormat format of DATE-TIME in UTC.
+const utcFormat = "20060102T150405Z"
+
+// Write calendar with name and events to w. Start and end of events are written in UTC,
+// Props of events are written as additional properties (X-...), after standard ones.
+func Write(w io.Writer, name string, events []Event) error {
+	buf := bufio.NewWriter(w)
+	stamp := time.Now().UTC().Format(utcFormat)
+
+	writeLine(buf, "BEGIN:VCALENDAR")
+	writeLine(buf, "VERSION:2.0")
+	writeLine(buf, "PRODID:-//silences-sheduler//EN")
+	writeLine(buf, "CALSCALE:GREGORIAN")
+
+	if name != "" {
+		writeLine(buf, "X-WR-CALNAME:"+escape(name))
+	}
+
+	for _, event := range events {
+		writeLine(buf, "BEGIN:VEVENT")
+		writeLine(buf, "UID:"+escape(event.UID))
+		writeLine(buf, "DTSTAMP:"+stamp)
+		writeLine(buf, "DTSTART:"+event.Start.UTC().Format(utcFormat))
+		writeLine(buf, "DTEND:"+event.End.UTC().Format(utcFormat))
+		writeLine(buf, "SUMMARY:"+escape(event.Summary))
+
+		if event.Description != "" {
+			writeLine(buf, "DESCRIPTION:"+escape(event.Description))
+		}
+
+		for _, prop := range event.Props {
+			writeLine(buf, prop.Name+":"+escape(prop.Value))
+		}
+
+		writeLine(buf, "END:VEVENT")
+	}
+
+	writeLine(buf, "END:VCALENDAR")
+
+	return buf.Flush()
+}
+
+// writeLine write content line with CRLF, folded by lineLength octets without splitting UTF-8 characters.
+func writeLine(w *bufio.Writer, line string) {
+	limit := lineLength
+
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		limit = lineLength - 1 // continuation line starts with space.
+	}
+
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,53 @@
+package ical_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/ical"
+)
+
+func TestWrite(t *testing.T) {
+	start := time.Date(2026, 11, 3, 22, 0, 0, 0, time.FixedZone("", 3*60*60))
+	description := "Matchers: alertname=\"DiskLatency1s\"; instance=~\"db.*\", " + strings.Repeat("длинное описание ", 10)
+
+	events := []ical.Event{{
+		UID:         "backup@silences-sheduler",
+		Summary:     "Silence: Backup, night",
+		Description: description,
+		Start:       start,
+		End:         start.Add(8 * time.Hour),
+	}}
+
+	var buf bytes.Buffer
+
+	if err := ical.Write(&buf, "Silences", events); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Write() line longer than 75 octets: %q", line)
+		}
+	}
+
+	parsed, err := ical.Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(parsed) != 1 {
+		t.Fatalf("Parse() events = %v, want 1", len(parsed))
+	}
+
+	got := parsed[0]
+	if got.UID != events[0].UID || got.Summary != events[0].Summary || got.Description != description {
+		t.Errorf("Parse() event = %#v", got)
+	}
+
+	if !got.Start.Equal(start) || !got.End.Equal(start.Add(8*time.Hour)) {
+		t.Errorf("Parse() event window %v - %v", got.Start, got.End)
+	}
+}
@@ -1,15 +1,22 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/Volkov-Stanislav/silences-sheduler/metrics"
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
 	"github.com/Volkov-Stanislav/silences-sheduler/service"
+	"github.com/Volkov-Stanislav/silences-sheduler/sinks"
 	"github.com/Volkov-Stanislav/silences-sheduler/stats"
 	"github.com/Volkov-Stanislav/silences-sheduler/storages"
+	"github.com/Volkov-Stanislav/silences-sheduler/webhooks"
 	"github.com/namsral/flag"
 
 	"go.uber.org/zap"
@@ -21,6 +28,26 @@ var (
 	apiurl         string
 	metricsPort    string
 	statPort       string
+	csvComment     string
+	csvCreatedBy   string
+	tenant         string
+	grafanaURL     string
+	grafanaToken   string
+	pagerDutyURL   string
+	pagerDutyToken string
+	pagerDutyFrom  string
+	zabbixURL      string
+	zabbixToken    string
+	csvZabbix      string
+	webhookRetries int
+	pausesFile     string
+	activeFile     string
+	controlToken   string
+	trustedProxy   bool
+	freeze         bool
+	freezeExpire   bool
+	policyFile     string
+	shutdownGrace  time.Duration
 )
 
 func main() {
@@ -30,23 +57,55 @@ func main() {
 	flag.StringVar(&statPort, "statistic_port", "38080", "port for statistics")
 	flag.StringVar(&shedulesDir, "shedules_dir", "shedule_configs", "path to shedule configs")
 	flag.StringVar(&apiurl, "apiurl", "http://localhost:9093/api/v2/silences", "alertmanager API URL")
+	flag.StringVar(&tenant, "tenant", "", "default Alertmanager tenant (X-Scope-OrgID), empty for single tenant Alertmanager")
+	flag.StringVar(&grafanaURL, "grafana_url", "", "Grafana URL for sections with 'sink: grafana', empty for disable")
+	flag.StringVar(&grafanaToken, "grafana_token_file", "", "path to file with Grafana service account token")
+	flag.StringVar(&pagerDutyURL, "pagerduty_url", sinks.PagerDutyAPIURL, "PagerDuty REST API URL")
+	flag.StringVar(&pagerDutyToken, "pagerduty_token_file", "", "path to file with PagerDuty API token, empty for disable PagerDuty target")
+	flag.StringVar(&pagerDutyFrom, "pagerduty_from", "", "email of PagerDuty user for maintenance windows requests")
+	flag.StringVar(&zabbixURL, "zabbix_url", "", "Zabbix JSON-RPC URL (.../api_jsonrpc.php), empty for disable Zabbix target")
+	flag.StringVar(&zabbixToken, "zabbix_token_file", "", "path to file with Zabbix API token")
+	flag.StringVar(&csvZabbix, "csv_zabbix", "false", "create Zabbix maintenance periods for hosts of CSV shedules")
+	flag.IntVar(&webhookRetries, "webhook_retries", 3, "count of retries of failed webhook requests")
+	flag.BoolVar(&freeze, "freeze", false, "start in change-freeze mode, shedules don't create silences")
+	flag.BoolVar(&freezeExpire, "freeze_expire", false, "expire silences created by scheduler when freeze begin")
+	flag.StringVar(&policyFile, "policy_file", "", "path to policy file with mandatory and forbidden matchers of all silences")
+	flag.DurationVar(&shutdownGrace, "shutdown_grace", 30*time.Second, "grace period on shutdown for running shedules and in-flight requests")
+	flag.StringVar(&controlToken, "control_token_file", "", "path to file with bearer token of control API (pause, resume, skip, run, freeze)")
+	flag.BoolVar(&trustedProxy, "trusted_proxy", false, "statistic port is behind auth proxy, which set X-Forwarded-User header")
+	flag.StringVar(&activeFile, "active_file", "active.json", "path to file with silences created by scheduler, empty for not persistent registry")
+	flag.StringVar(&pausesFile, "pauses_file", "pauses.json", "path to file with runtime pauses of sections and shedules, empty for not persistent pauses")
+	flag.StringVar(&csvComment, "csv_comment", "", "template of silence comment for CSV shedules")
+	flag.StringVar(&csvCreatedBy, "csv_created_by", "", "template of silence createdBy for CSV shedules")
 	flag.Parse()
 
-	fmt.Println(updateInterval)
-	fmt.Println(shedulesDir)
-	fmt.Println(apiurl)
-
 	config := make(map[string]string)
 	config["shedules_dir"] = shedulesDir
 	config["update_interval"] = updateInterval
 	config["metrics_port"] = metricsPort
 	config["statistic_port"] = statPort
+	config["csv_comment"] = csvComment
+	config["csv_created_by"] = csvCreatedBy
+	config["csv_zabbix"] = csvZabbix
+	config["policy_file"] = policyFile
+	config["control_token_file"] = controlToken
+
+	switch flag.Arg(0) {
+	case "next":
+		os.Exit(runNext(config, flag.Args()[1:]))
+	case "validate":
+		os.Exit(runValidate(config, flag.Args()[1:]))
+	case "skip", "run-now":
+		os.Exit(runAction(config, flag.Arg(0), flag.Args()[1:]))
+	}
+
+	fmt.Println(updateInterval)
+	fmt.Println(shedulesDir)
+	fmt.Println(apiurl)
 
 	prom := metrics.NewPrometheusInstance(metricsPort)
 	prom.Run()
 
-	defer prom.Stop()
-
 	log, err := zap.NewDevelopment()
 	if err != nil {
 		panic(fmt.Sprintf("Error initialithing logging:  (%v)?", err))
@@ -57,24 +116,110 @@ func main() {
 	stat := stats.NewInstance(statPort, log)
 	stat.Run()
 
-	defer stat.Stop()
+	sinkList := map[string]models.Sink{
+		models.DefaultSink: sinks.NewAlertmanager(apiurl),
+	}
+
+	if grafanaURL != "" {
+		grafana, err := sinks.NewGrafana(grafanaURL, grafanaToken)
+		if err != nil {
+			log.Sugar().Errorf("Error get Grafana sink: %v", err)
+		} else {
+			sinkList["grafana"] = grafana
+		}
+	}
+
+	if pagerDutyToken != "" {
+		pagerDuty, err := sinks.NewPagerDuty(pagerDutyURL, pagerDutyToken, pagerDutyFrom)
+		if err != nil {
+			log.Sugar().Errorf("Error get PagerDuty target: %v", err)
+		} else {
+			sinkList[models.PagerDutySink] = pagerDuty
+		}
+	}
+
+	if zabbixURL != "" {
+		zabbix, err := sinks.NewZabbix(zabbixURL, zabbixToken)
+		if err != nil {
+			log.Sugar().Errorf("Error get Zabbix target: %v", err)
+		} else {
+			sinkList[models.ZabbixSink] = zabbix
+		}
+	}
+
+	if _, ok := sinkList[models.ZabbixSink]; !ok && csvZabbix == "true" {
+		log.Sugar().Errorf("csv_zabbix is true, but Zabbix target is not configured (zabbix_url), CSV shedules create only silences")
+	}
+
+	notifier := webhooks.NewNotifier(webhookRetries, time.Second, log)
+
+	serv, _ := service.NewRunner(sinkList, notifier, tenant, log, stat, prom)
+
+	pauses, err := models.NewPauses(pausesFile)
+	if err != nil {
+		log.Sugar().Errorf("Error load pauses from %v: %v", pausesFile, err)
+	}
+
+	active, err := models.NewActiveSilences(activeFile, time.Now())
+	if err != nil {
+		log.Sugar().Errorf("Error load active silences from %v: %v", activeFile, err)
+	}
+
+	policy, err := models.NewPolicy(policyFile)
+	if err != nil {
+		log.Sugar().Fatalf("Error load policy from %v: %v", policyFile, err)
+	}
+
+	auth, err := service.NewControlAuth(controlToken, trustedProxy)
+	if err != nil {
+		log.Sugar().Fatalf("Error load control API token: %v", err)
+	}
 
-	serv, _ := service.NewRunner(apiurl, log, stat, prom)
+	if auth.Token == "" && !auth.TrustedProxy {
+		log.Warn("Control API is read-only, set control_token_file or trusted_proxy to enable it")
+	}
+
+	serv.SetPauses(pauses)
+	serv.SetControlAuth(auth)
+	serv.SetActive(active)
+	serv.SetPolicy(policy)
+	serv.SetFreeze(models.NewFreeze(freeze, filepath.Join(shedulesDir, models.FreezeFileName)), freezeExpire)
 	serv.Start()
 
+	stat.Handle("/shedules.ics", "silences_sheduler_requests_calendar_total", http.HandlerFunc(serv.ServeCalendar))
+	stat.Handle("/pause", "silences_sheduler_requests_pause_total", serv.PauseHandler(false))
+	stat.Handle("/resume", "silences_sheduler_requests_resume_total", serv.PauseHandler(true))
+	stat.Handle("/pauses", "silences_sheduler_requests_pauses_total", serv.PausesHandler())
+	stat.Handle("/skip", "silences_sheduler_requests_skip_total", serv.SkipHandler())
+	stat.Handle("/run", "silences_sheduler_requests_run_total", serv.RunNowHandler())
+	stat.Handle("/freeze", "silences_sheduler_requests_freeze_total", serv.FreezeHandler())
+	stat.Handle("/next", "silences_sheduler_requests_next_total", serv.PreviewHandler(func(fileName string) ([]models.SheduleSection, error) {
+		return storages.ReadFile(fileName, config, log)
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
 	shedcsv, err := storages.GetCSVStorage(config, log)
 	if err != nil {
 		fmt.Printf("Error get CSV storage object: %v", err)
 	}
 
-	shedcsv.Run(serv.GetChannels())
+	add, del := serv.GetChannels()
+	shedcsv.Run(ctx, add, del)
 
 	shedyaml, err := storages.GetYAMLStorage(config, log)
 	if err != nil {
 		fmt.Printf("Error get YAML storage object: %v", err)
 	}
 
-	shedyaml.Run(serv.GetChannels())
+	shedyaml.Run(ctx, add, del)
+
+	shedics, err := storages.GetICSStorage(config, log)
+	if err != nil {
+		fmt.Printf("Error get ICS storage object: %v", err)
+	}
+
+	shedics.Run(ctx, add, del)
 
 	var (
 		hup  = make(chan os.Signal, 1)
@@ -87,10 +232,48 @@ func main() {
 	for {
 		select {
 		case <-hup:
-			log.Info("Received SIGHUP, do nothing...")
+			log.Info("Received SIGHUP, reload policy")
+
+			if err := policy.Reload(); err != nil {
+				log.Sugar().Errorf("Error reload policy from %v: %v", policyFile, err)
+			}
 		case <-term:
 			log.Info("Received SIGTERM, exiting gracefully...")
+			cancel()
+
+			for _, storage := range []interface{ Done() <-chan struct{} }{shedcsv, shedyaml, shedics} {
+				<-storage.Done()
+			}
+
+			shutdown(serv, notifier, stat, prom, log)
+
 			return
 		}
 	}
 }
+
+// shutdown stop runner, then webhooks notifier, statistic and metrics servers, with common grace period.
+func shutdown(serv *service.Runner, notifier *webhooks.Notifier, stat *stats.Instance, prom *metrics.Instance, log *zap.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownGrace)
+	defer cancel()
+
+	if err := serv.Shutdown(ctx); err != nil {
+		log.Sugar().Errorf("Error stop runner, running shedules cancelled: %v", err)
+	}
+
+	if err := notifier.Shutdown(ctx); err != nil {
+		log.Sugar().Errorf("Error send webhook events, sending cancelled: %v", err)
+	}
+
+	if err := stat.Shutdown(ctx); err != nil {
+		log.Sugar().Errorf("Error stop statistic server: %v", err)
+		stat.Stop()
+	}
+
+	if err := prom.Shutdown(ctx); err != nil {
+		log.Sugar().Errorf("Error stop metrics server: %v", err)
+		prom.Stop()
+	}
+
+	log.Info("Shutdown complete")
+}
@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"context"
+	"errors"
 	"net/http"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -11,7 +13,12 @@ import (
 // Instance is metrics instance.
 type Instance struct {
 	metricsPort    string
-	silencesSetted prometheus.Counter
+	silencesSetted *prometheus.CounterVec
+	silencesErrors *prometheus.CounterVec
+	skipped        *prometheus.CounterVec
+	overlaps       *prometheus.GaugeVec
+	paused         *prometheus.GaugeVec
+	freeze         prometheus.Gauge
 	srv            *http.Server
 }
 
@@ -29,9 +36,9 @@ func NewPrometheusInstance(metricsPort string) *Instance {
 func (o *Instance) Run() {
 	go func() {
 		http.Handle("/metrics", promhttp.Handler())
-		err := http.ListenAndServe(":"+o.metricsPort, nil)
+		err := o.srv.ListenAndServe()
 
-		if err != nil {
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			panic(err)
 		}
 	}()
@@ -42,17 +49,82 @@ func (o *Instance) Stop() {
 	o.srv.Close()
 }
 
+// Shutdown gracefully stop metrics server, waiting for active scrapes until ctx is done.
+func (o *Instance) Shutdown(ctx context.Context) error {
+	return o.srv.Shutdown(ctx)
+}
+
 func (o *Instance) register() {
 	// Register additional metrics.
-	o.silencesSetted = promauto.NewCounter(
+	o.silencesSetted = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "silences_sheduler_silences_setted",
 			Help: "How many silences setted since run.",
 		},
+		[]string{"tenant", "sink"},
+	)
+	o.silencesErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "silences_sheduler_silences_errors",
+			Help: "How many silences failed to set since run.",
+		},
+		[]string{"tenant", "sink"},
+	)
+	o.skipped = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "silences_sheduler_silences_skipped",
+			Help: "How many shedule runs skipped since run, by reason.",
+		},
+		[]string{"reason"},
+	)
+	o.overlaps = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "silences_sheduler_overlaps",
+			Help: "Count of overlapping shedule pairs (overlap) and shedules with stacking windows (stacking) in running sections.",
+		},
+		[]string{"kind"},
+	)
+	o.paused = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "silences_sheduler_paused",
+			Help: "Count of active runtime pauses by kind (section, shedule).",
+		},
+		[]string{"kind"},
 	)
+	o.freeze = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "silences_sheduler_freeze",
+			Help: "1 if global change-freeze is active and shedules don't create silences.",
+		},
+	)
+}
+
+// AddSilencesCounter increase count runned silences of tenant in sink.
+func (o *Instance) AddSilencesCounter(tenant, sink string, count float64) {
+	o.silencesSetted.WithLabelValues(tenant, sink).Add(count)
+}
+
+// AddSilencesErrors increase count failed silences of tenant in sink.
+func (o *Instance) AddSilencesErrors(tenant, sink string, count float64) {
+	o.silencesErrors.WithLabelValues(tenant, sink).Add(count)
+}
+
+// AddSkipped increase count skipped shedule runs with reason.
+func (o *Instance) AddSkipped(reason string, count float64) {
+	o.skipped.WithLabelValues(reason).Add(count)
+}
+
+// SetOverlaps set count of found overlaps of kind.
+func (o *Instance) SetOverlaps(kind string, count float64) {
+	o.overlaps.WithLabelValues(kind).Set(count)
+}
+
+// SetPaused set count of active pauses of kind.
+func (o *Instance) SetPaused(kind string, count float64) {
+	o.paused.WithLabelValues(kind).Set(count)
 }
 
-// AddSilencesCounter increase count runned silences.
-func (o *Instance) AddSilencesCounter(count float64) {
-	o.silencesSetted.Add(count)
+// SetFreeze set state of freeze, 1 for active freeze.
+func (o *Instance) SetFreeze(value float64) {
+	o.freeze.Set(value)
 }
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// ActiveSilence silence created by scheduler, with not passed end.
+type ActiveSilence struct {
+	Key    string // Key of shedule.
+	Sink   string // Name of sink.
+	ID     string // ID of silence in sink.
+	Window Window // Window of silence.
+}
+
+// activeRecord active silence in file of registry, window keeps only tenant, start, end and startsAt of silence,
+// which is required to extend silence.
+type activeRecord struct {
+	Key      string    `json:"key"`
+	Sink     string    `json:"sink"`
+	ID       string    `json:"id"`
+	Tenant   string    `json:"tenant,omitempty"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	StartsAt time.Time `json:"startsAt"`
+}
+
+// ActiveSilences registry of silences created by scheduler, saved in file for restarts.
+type ActiveSilences struct {
+	mux   sync.Mutex
+	path  string
+	items map[string]ActiveSilence
+}
+
+// NewActiveSilences return registry of active silences, loaded from file path.
+// Empty path for registry without persistence. Ended silences are not loaded.
+func NewActiveSilences(path string, now time.Time) (*ActiveSilences, error) {
+	result := &ActiveSilences{path: path, items: make(map[string]ActiveSilence)}
+
+	if path == "" {
+		return result, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return result, nil
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	var list []activeRecord
+
+	if err := json.Unmarshal(data, &list); err != nil {
+		return result, err
+	}
+
+	for _, record := range list {
+		if record.End.After(now) {
+			result.items[record.Key+"|"+record.Sink] = ActiveSilence{Key: record.Key, Sink: record.Sink, ID: record.ID,
+				Window: Window{Tenant: record.Tenant, Start: record.Start, End: record.End, Silence: Silence{StartsAt: record.StartsAt}}}
+		}
+	}
+
+	return result, nil
+}
+
+// Get return active silence of shedule key in sink, not ended at now.
+func (o *ActiveSilences) Get(key, sink string, now time.Time) (ActiveSilence, bool) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	active, ok := o.items[key+"|"+sink]
+	if !ok || !active.Window.End.After(now) {
+		return ActiveSilence{}, false
+	}
+
+	return active, true
+}
+
+// Set add or replace active silence and save registry.
+func (o *ActiveSilences) Set(active ActiveSilence) error {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	o.items[active.Key+"|"+active.Sink] = active
+
+	return o.save()
+}
+
+// Remove active silence and save registry.
+func (o *ActiveSilences) Remove(active ActiveSilence) error {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	if current, ok := o.items[active.Key+"|"+active.Sink]; !ok || current.ID != active.ID {
+		return nil
+	}
+
+	delete(o.items, active.Key+"|"+active.Sink)
+
+	return o.save()
+}
+
+// Ended remove silence ended at now and save registry, return false if silence was expired, replaced or extended before.
+func (o *ActiveSilences) Ended(active ActiveSilence, now time.Time) (bool, error) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	current, ok := o.items[active.Key+"|"+active.Sink]
+	if !ok || current.ID != active.ID || current.Window.End.After(now) {
+		return false, nil
+	}
+
+	delete(o.items, active.Key+"|"+active.Sink)
+
+	return true, o.save()
+}
+
+// List return all silences active at now.
+func (o *ActiveSilences) List(now time.Time) []ActiveSilence {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	result := make([]ActiveSilence, 0, len(o.items))
+
+	for _, active := range o.items {
+		if active.Window.End.After(now) {
+			result = append(result, active)
+		}
+	}
+
+	return result
+}
+
+// save write active silences to file, must be called with locked mux.
+func (o *ActiveSilences) save() error {
+	if o.path == "" {
+		return nil
+	}
+
+	list := make([]activeRecord, 0, len(o.items))
+	for _, active := range o.items {
+		list = append(list, activeRecord{Key: active.Key, Sink: active.Sink, ID: active.ID, Tenant: active.Window.Tenant,
+			Start: active.Window.Start, End: active.Window.End, StartsAt: active.Window.Silence.StartsAt})
+	}
+
+	return saveJSON(o.path, list)
+}
@@ -0,0 +1,158 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/ical"
+)
+
+const (
+	// dateLayout layout of dates in calendars.
+	dateLayout = "2006-01-02"
+	// calendarYears count of years, for which recurring events of ICS calendars are expanded.
+	calendarYears = 5
+)
+
+// Calendar exclusion calendar: dates, when shedules referencing it don't create silences.
+type Calendar struct {
+	Dates  []string    `yaml:"dates"`  // Excluded dates, "2006-01-02".
+	Ranges []DateRange `yaml:"ranges"` // Excluded ranges of dates.
+	ICS    string      `yaml:"ics"`    // .ics file with excluded days, path relative to section file.
+	days   map[string]bool
+}
+
+// DateRange range of dates, both ends included.
+type DateRange struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+}
+
+// Load check dates of calendar and read its ICS file, relative paths resolved from baseDir.
+// Recurring events of ICS file are expanded around now.
+func (o *Calendar) Load(baseDir string, now time.Time) error {
+	o.days = make(map[string]bool)
+
+	for _, date := range o.Dates {
+		if _, err := time.Parse(dateLayout, date); err != nil {
+			return fmt.Errorf("invalid date %q: %w", date, err)
+		}
+
+		o.days[date] = true
+	}
+
+	for _, rng := range o.Ranges {
+		from, err := time.Parse(dateLayout, rng.From)
+		if err != nil {
+			return fmt.Errorf("invalid range start %q: %w", rng.From, err)
+		}
+
+		to, err := time.Parse(dateLayout, rng.To)
+		if err != nil {
+			return fmt.Errorf("invalid range end %q: %w", rng.To, err)
+		}
+
+		if to.Before(from) {
+			return fmt.Errorf("range end %v before start %v", rng.To, rng.From)
+		}
+	}
+
+	if o.ICS == "" {
+		return nil
+	}
+
+	path := o.ICS
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	events, err := ical.Parse(file)
+	if err != nil {
+		return fmt.Errorf("parse %v: %w", path, err)
+	}
+
+	for _, event := range events {
+		dates := event.Dates()
+		if event.Recurring() {
+			dates = event.DatesBetween(now.AddDate(-1, 0, 0), now.AddDate(calendarYears, 0, 0))
+		}
+
+		for _, date := range dates {
+			o.days[date] = true
+		}
+	}
+
+	return nil
+}
+
+// Excludes check if date of t is excluded by calendar.
+func (o *Calendar) Excludes(t time.Time) bool {
+	date := t.Format(dateLayout)
+
+	if o.days[date] {
+		return true
+	}
+
+	for _, rng := range o.Ranges {
+		if rng.From <= date && date <= rng.To {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CalendarSet calendars of all running sections, for references between sections.
+type CalendarSet struct {
+	mux       sync.RWMutex
+	bySection map[string]map[string]*Calendar
+}
+
+// NewCalendarSet return empty CalendarSet.
+func NewCalendarSet() *CalendarSet {
+	return &CalendarSet{bySection: make(map[string]map[string]*Calendar)}
+}
+
+// Set calendars of section with token.
+func (o *CalendarSet) Set(token string, calendars map[string]*Calendar) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	if len(calendars) == 0 {
+		delete(o.bySection, token)
+		return
+	}
+
+	o.bySection[token] = calendars
+}
+
+// Remove calendars of section with token.
+func (o *CalendarSet) Remove(token string) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	delete(o.bySection, token)
+}
+
+// Get calendar by name.
+func (o *CalendarSet) Get(name string) (*Calendar, bool) {
+	o.mux.RLock()
+	defer o.mux.RUnlock()
+
+	for _, calendars := range o.bySection {
+		if calendar, ok := calendars[name]; ok {
+			return calendar, true
+		}
+	}
+
+	return nil, false
+}
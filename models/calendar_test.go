@@ -0,0 +1,90 @@
+package models_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+func TestCalendar_Excludes(t *testing.T) {
+	dir := t.TempDir()
+	ics := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Victory Day\nDTSTART;VALUE=DATE:20270509\nEND:VEVENT\nEND:VCALENDAR\n"
+
+	if err := os.WriteFile(filepath.Join(dir, "holidays.ics"), []byte(ics), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	calendar := models.Calendar{
+		Dates:  []string{"2027-01-07"},
+		Ranges: []models.DateRange{{From: "2026-12-28", To: "2027-01-03"}},
+		ICS:    "holidays.ics",
+	}
+
+	if err := calendar.Load(dir, time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tests := []struct {
+		date string
+		want bool
+	}{
+		{"2026-12-27", false},
+		{"2026-12-28", true},
+		{"2027-01-03", true},
+		{"2027-01-04", false},
+		{"2027-01-07", true},
+		{"2027-05-09", true},
+		{"2027-05-10", false},
+	}
+
+	for _, tt := range tests {
+		date, _ := time.Parse("2006-01-02", tt.date)
+		if got := calendar.Excludes(date.Add(2 * time.Hour)); got != tt.want {
+			t.Errorf("Excludes(%v) = %v, want %v", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestCalendar_LoadInvalid(t *testing.T) {
+	calendar := models.Calendar{Ranges: []models.DateRange{{From: "2027-01-03", To: "2026-12-28"}}}
+
+	if err := calendar.Load(t.TempDir(), time.Now()); err == nil {
+		t.Error("Load() error = nil, want error for reversed range")
+	}
+}
+
+func TestCalendar_LoadRecurringAtNow(t *testing.T) {
+	dir := t.TempDir()
+	ics := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Victory Day\nDTSTART;VALUE=DATE:20200509\nRRULE:FREQ=YEARLY\nEND:VEVENT\nEND:VCALENDAR\n"
+
+	if err := os.WriteFile(filepath.Join(dir, "holidays.ics"), []byte(ics), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		date time.Time
+		want bool
+	}{
+		{name: "Next year", now: time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC), date: time.Date(2041, 5, 9, 2, 0, 0, 0, time.UTC), want: true},
+		{name: "Beyond expanded years", now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), date: time.Date(2041, 5, 9, 2, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calendar := models.Calendar{ICS: "holidays.ics"}
+
+			if err := calendar.Load(dir, tt.now); err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			if got := calendar.Excludes(tt.date); got != tt.want {
+				t.Errorf("Excludes(%v) = %v, want %v", tt.date, got, tt.want)
+			}
+		})
+	}
+}
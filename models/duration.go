@@ -0,0 +1,97 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Volkov-Stanislav/cron"
+)
+
+// cronParser parser of cron expressions with seconds, same as in cron of sections.
+var cronParser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// Duration of silence. In YAML it is int count of seconds (2400), or Go duration string ("40m", "3h30m").
+type Duration time.Duration
+
+// UnmarshalYAML parse seconds or duration string.
+func (o *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value string
+
+	if err := unmarshal(&value); err != nil {
+		return err
+	}
+
+	d, err := ParseDuration(value)
+	if err != nil {
+		return err
+	}
+
+	*o = d
+
+	return nil
+}
+
+// String stringer interface.
+func (o Duration) String() string {
+	return time.Duration(o).String()
+}
+
+// ParseDuration parse count of seconds or Go duration string.
+func ParseDuration(value string) (Duration, error) {
+	value = strings.TrimSpace(value)
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return Duration(time.Duration(seconds) * time.Second), nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+	}
+
+	return Duration(d), nil
+}
+
+// untilEnd return end of window started at start, defined by until: time of day ("06:00", "06:00:00"),
+// next after start, or cron (or relative) expression, next activation after start. Computed in loc.
+func untilEnd(until string, start time.Time, loc *time.Location) (time.Time, error) {
+	until = strings.TrimSpace(until)
+	start = start.In(loc)
+
+	if !strings.Contains(until, " ") {
+		var hour, minute, second int
+
+		layout := "%d:%d"
+		args := []interface{}{&hour, &minute}
+
+		if strings.Count(until, ":") == 2 {
+			layout = "%d:%d:%d"
+			args = append(args, &second)
+		}
+
+		if _, err := fmt.Sscanf(until, layout, args...); err != nil || hour > 23 || minute > 59 || second > 59 {
+			return time.Time{}, fmt.Errorf("invalid until time %q", until)
+		}
+
+		end := time.Date(start.Year(), start.Month(), start.Day(), hour, minute, second, 0, loc)
+		if !end.After(start) {
+			end = time.Date(start.Year(), start.Month(), start.Day()+1, hour, minute, second, 0, loc)
+		}
+
+		return end, nil
+	}
+
+	schedule, err := ParseSchedule(until, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid until cron %q: %w", until, err)
+	}
+
+	end := schedule.Next(start)
+	if end.IsZero() {
+		return end, fmt.Errorf("until cron %q never activated after %v", until, start)
+	}
+
+	return end, nil
+}
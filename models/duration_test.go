@@ -0,0 +1,105 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"gopkg.in/yaml.v2"
+)
+
+func TestDuration_UnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "Seconds", value: "duration: 2400", want: 40 * time.Minute},
+		{name: "Go duration", value: "duration: 3h30m", want: 3*time.Hour + 30*time.Minute},
+		{name: "Invalid", value: "duration: three hours", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var shed models.Shedule
+
+			err := yaml.Unmarshal([]byte(tt.value), &shed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && time.Duration(shed.Duration) != tt.want {
+				t.Errorf("Duration = %v, want %v", time.Duration(shed.Duration), tt.want)
+			}
+		})
+	}
+}
+
+func TestShedule_WindowEnd(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	start := time.Date(2026, 11, 3, 22, 0, 0, 0, moscow)
+
+	tests := []struct {
+		name string
+		shed models.Shedule
+		want time.Time
+	}{
+		{
+			name: "Duration",
+			shed: models.Shedule{Duration: models.Duration(40 * time.Minute)},
+			want: start.Add(40 * time.Minute),
+		},
+		{
+			name: "Until time of next day",
+			shed: models.Shedule{Duration: 60, Until: "06:00"},
+			want: time.Date(2026, 11, 4, 6, 0, 0, 0, moscow),
+		},
+		{
+			name: "Until time of same day",
+			shed: models.Shedule{Until: "23:30:00"},
+			want: time.Date(2026, 11, 3, 23, 30, 0, 0, moscow),
+		},
+		{
+			name: "Until cron",
+			shed: models.Shedule{Until: "0 0 4 * * sat"},
+			want: time.Date(2026, 11, 7, 4, 0, 0, 0, moscow),
+		},
+		{
+			name: "One-off end",
+			shed: models.Shedule{Start: "2026-11-03T22:00:00+03:00", End: "2026-11-04T04:00:00+03:00"},
+			want: time.Date(2026, 11, 4, 4, 0, 0, 0, moscow),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section := models.SheduleSection{TimeOffset: "3", Shedules: []models.Shedule{tt.shed}}
+			if err := section.CheckWindows(start); err != nil {
+				t.Fatalf("CheckWindows() error = %v", err)
+			}
+
+			if got := section.Shedules[0].WindowEnd(start); !got.Equal(tt.want) {
+				t.Errorf("WindowEnd() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShedule_WindowEndDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("no time zone database")
+	}
+
+	// Night of switch from summer time, 22:00 until 06:00 is 9 hours.
+	start := time.Date(2026, 10, 24, 22, 0, 0, 0, berlin)
+	section := models.SheduleSection{TimeOffset: "Europe/Berlin", Shedules: []models.Shedule{{Until: "06:00"}}}
+	if err := section.CheckWindows(start); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := section.Shedules[0].WindowEnd(start); got.Sub(start) != 9*time.Hour {
+		t.Errorf("WindowEnd() = %v, duration %v, want 9h", got, got.Sub(start))
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// EventType type of silence lifecycle event.
+type EventType string
+
+// Types of silence lifecycle events.
+const (
+	EventCreated  EventType = "created"
+	EventFailed   EventType = "failed"
+	EventExtended EventType = "extended"
+	EventExpired  EventType = "expired"
+)
+
+// Event silence lifecycle event, sent to webhooks of section.
+type Event struct {
+	Type    EventType       `json:"type"`
+	Time    time.Time       `json:"time"`
+	Section string          `json:"section"`
+	File    string          `json:"file"`
+	Sink    string          `json:"sink"`
+	Tenant  string          `json:"tenant,omitempty"`
+	ID      string          `json:"id,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Start   time.Time       `json:"start"`
+	End     time.Time       `json:"end"`
+	Silence Silence         `json:"silence"`
+	Targets []WebhookTarget `json:"-"` // Webhooks of section.
+}
+
+// Notifier send silence lifecycle events.
+type Notifier interface {
+	Notify(e Event)
+}
+
+// Extender is optional interface of Sink, which can prolong silence created earlier.
+type Extender interface {
+	// Extend silence with ID up to end of window, return ID of extended silence.
+	Extend(ctx context.Context, w Window, id string) (string, error)
+}
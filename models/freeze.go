@@ -0,0 +1,79 @@
+package models
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// FreezeFileName name of sentinel file in shedules directory, which existence set freeze.
+const FreezeFileName = "FREEZE"
+
+// Sources of freeze.
+const (
+	FreezeFlag = "flag" // Freeze set by command line flag.
+	FreezeFile = "file" // Freeze set by sentinel file.
+	FreezeAPI  = "api"  // Freeze set by API.
+)
+
+// FreezeState state of freeze.
+type FreezeState struct {
+	Frozen bool      `json:"frozen"`
+	Source string    `json:"source,omitempty"` // flag, file or api.
+	Since  time.Time `json:"since,omitempty"`  // Time of freeze by API.
+	By     string    `json:"by,omitempty"`     // User froze by API.
+	Reason string    `json:"reason,omitempty"`
+}
+
+// Freeze global change-freeze switch: while frozen, shedules don't create silences.
+// Freeze is set by flag, by existence of sentinel file, or by API.
+type Freeze struct {
+	mux  sync.Mutex
+	flag bool
+	file string
+	api  FreezeState
+}
+
+// NewFreeze return freeze switch, frozen by flag or by existence of file. Empty file for switch without sentinel file.
+func NewFreeze(flag bool, file string) *Freeze {
+	return &Freeze{flag: flag, file: file}
+}
+
+// Set freeze by API at now.
+func (o *Freeze) Set(by, reason string, now time.Time) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	o.api = FreezeState{Frozen: true, Source: FreezeAPI, Since: now.UTC(), By: by, Reason: reason}
+}
+
+// Clear freeze set by API, freeze by flag and sentinel file stay.
+func (o *Freeze) Clear() {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	o.api = FreezeState{}
+}
+
+// State return current state of freeze.
+func (o *Freeze) State() FreezeState {
+	if o == nil {
+		return FreezeState{}
+	}
+
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	switch {
+	case o.api.Frozen:
+		return o.api
+	case o.flag:
+		return FreezeState{Frozen: true, Source: FreezeFlag}
+	case o.file != "":
+		if info, err := os.Stat(o.file); err == nil {
+			return FreezeState{Frozen: true, Source: FreezeFile, Since: info.ModTime().UTC(), Reason: "sentinel file " + o.file}
+		}
+	}
+
+	return FreezeState{}
+}
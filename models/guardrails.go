@@ -0,0 +1,183 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Guardrails limits of silences against overly broad or overly long silences, part of policy.
+// Zero values disable limits.
+type Guardrails struct {
+	MinMatchers    int      `yaml:"minMatchers"`    // Minimum count of matchers of silence, without mandatory matchers of policy.
+	CatchAll       []string `yaml:"catchAll"`       // Regexps of values of regex matchers, which match everything (".*").
+	RequiredLabels []string `yaml:"requiredLabels"` // Label names, which must be in matchers of every silence.
+	MaxDuration    Duration `yaml:"maxDuration"`    // Maximum duration of silence, section may set lower limit.
+	MaxActive      int      `yaml:"maxActive"`      // Maximum count of active silences created by scheduler.
+	catchAll       []*regexp.Regexp
+}
+
+// compile regexps of guardrails.
+func (o *Guardrails) compile() error {
+	o.catchAll = nil
+
+	for _, value := range o.CatchAll {
+		re, err := regexp.Compile("^(?:" + value + ")$")
+		if err != nil {
+			return fmt.Errorf("catchAll %q: %w", value, err)
+		}
+
+		o.catchAll = append(o.catchAll, re)
+	}
+
+	return nil
+}
+
+// checkMatchers check count of matchers, catch-all regexps and required labels.
+func (o *Guardrails) checkMatchers(matchers []Matchers) error {
+	if len(matchers) < o.MinMatchers {
+		return fmt.Errorf("%v matchers, minimum %v", len(matchers), o.MinMatchers)
+	}
+
+	for _, matcher := range matchers {
+		if !matcher.IsRegex || !matcher.IsEqual {
+			continue
+		}
+
+		for _, re := range o.catchAll {
+			if re.MatchString(matcher.Value) {
+				return fmt.Errorf("catch-all matcher %v", matcher)
+			}
+		}
+	}
+
+	for _, label := range o.RequiredLabels {
+		found := false
+
+		for _, matcher := range matchers {
+			if matcher.Name == label {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("required label %v not in matchers", label)
+		}
+	}
+
+	return nil
+}
+
+// checkDuration check duration of silence with limit of section.
+func (o *Guardrails) checkDuration(d time.Duration, sectionMax Duration) error {
+	limit := o.MaxDuration
+	if sectionMax > 0 && (limit == 0 || sectionMax < limit) {
+		limit = sectionMax
+	}
+
+	if limit > 0 && d > time.Duration(limit) {
+		return fmt.Errorf("duration %v longer than maximum %v", d, limit)
+	}
+
+	return nil
+}
+
+// guardrails return guardrails of policy, zero guardrails for nil policy.
+func (o *Policy) guardrails() Guardrails {
+	if o == nil {
+		return Guardrails{}
+	}
+
+	o.mux.RLock()
+	defer o.mux.RUnlock()
+
+	return o.rules.Guardrails
+}
+
+// CheckGuardrails check shedule against guardrails of policy in env and maximum duration of its section: count of matchers,
+// catch-all regexps, required labels and duration of next window after now. Shedules failing check are refused.
+func (o *Shedule) CheckGuardrails(env *Environment, now time.Time) error {
+	var policy *Policy
+	if env != nil {
+		policy = env.Policy
+	}
+
+	guard := policy.guardrails()
+
+	if err := guard.checkMatchers(o.matchers()); err != nil {
+		return fmt.Errorf("guardrail: %w", err)
+	}
+
+	start, end, ok := o.nextWindow(now)
+	if !ok {
+		return nil
+	}
+
+	if err := guard.checkDuration(end.Sub(start), o.maxDuration()); err != nil {
+		return fmt.Errorf("guardrail: %w", err)
+	}
+
+	return nil
+}
+
+// checkWindow check window before posting against guardrails: matchers, duration and count of active silences.
+func (o *Shedule) checkWindow(env *Environment, window Window) error {
+	guard := env.Policy.guardrails()
+
+	if err := guard.checkMatchers(o.matchers()); err != nil {
+		return fmt.Errorf("guardrail: %w", err)
+	}
+
+	if err := guard.checkDuration(window.End.Sub(window.Start), o.maxDuration()); err != nil {
+		return fmt.Errorf("guardrail: %w", err)
+	}
+
+	if guard.MaxActive > 0 && env.Active != nil && !o.hasActive(env, window.Start) {
+		if active := len(env.Active.List(window.Start)); active >= guard.MaxActive {
+			return fmt.Errorf("guardrail: %v active silences created by scheduler, maximum %v", active, guard.MaxActive)
+		}
+	}
+
+	return nil
+}
+
+// maxDuration return maximum duration of silences of shedule section.
+func (o *Shedule) maxDuration() Duration {
+	if o.section == nil {
+		return 0
+	}
+
+	return o.section.MaxDuration
+}
+
+// nextWindow return start and end of next window of shedule after now, false if there is no such window.
+func (o *Shedule) nextWindow(now time.Time) (start, end time.Time, ok bool) {
+	if o.IsOneOff() {
+		start, end, err := o.OneOffWindow()
+		return start, end, err == nil
+	}
+
+	schedule, err := o.Schedule()
+	if err != nil {
+		return start, end, false
+	}
+
+	start = schedule.Next(now.In(o.location()))
+	if start.IsZero() {
+		return start, end, false
+	}
+
+	return start, o.WindowEnd(start), true
+}
+
+// hasActive check if shedule has active silence in one of its sinks, which will be extended.
+func (o *Shedule) hasActive(env *Environment, now time.Time) bool {
+	for _, sink := range o.sinks() {
+		if _, ok := env.Active.Get(o.Key(), sink.name, now); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,89 @@
+package models_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+func TestShedule_CheckGuardrails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	policy := `guardrails:
+  minMatchers: 2
+  catchAll: ['\.\*', '\.\+']
+  requiredLabels: [service]
+  maxDuration: 12h
+`
+
+	if err := os.WriteFile(path, []byte(policy), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := models.NewPolicy(path)
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+
+	env := &models.Environment{Policy: p}
+	now := time.Date(2026, 11, 3, 12, 0, 0, 0, time.Local)
+	service := models.Matchers{IsEqual: true, Name: "service", Value: "db"}
+	env2 := models.Matchers{IsEqual: true, Name: "env", Value: "prod"}
+
+	tests := []struct {
+		name        string
+		shed        models.Shedule
+		maxDuration time.Duration
+		wantErr     bool
+	}{
+		{
+			name: "Valid",
+			shed: models.Shedule{Cron: "0 0 22 * * *", Until: "06:00", Silence: models.Silence{Matchers: []models.Matchers{service, env2}}},
+		},
+		{
+			name:    "Too few matchers",
+			shed:    models.Shedule{Cron: "0 0 22 * * *", Duration: models.Duration(time.Hour), Silence: models.Silence{Matchers: []models.Matchers{service}}},
+			wantErr: true,
+		},
+		{
+			name: "Catch-all regex",
+			shed: models.Shedule{Cron: "0 0 22 * * *", Duration: models.Duration(time.Hour), Silence: models.Silence{Matchers: []models.Matchers{
+				service, {IsEqual: true, IsRegex: true, Name: "alertname", Value: ".*"},
+			}}},
+			wantErr: true,
+		},
+		{
+			name:    "Required label missing",
+			shed:    models.Shedule{Cron: "0 0 22 * * *", Duration: models.Duration(time.Hour), Silence: models.Silence{Matchers: []models.Matchers{env2, env2}}},
+			wantErr: true,
+		},
+		{
+			name:    "Longer than policy maximum",
+			shed:    models.Shedule{Start: "2026-11-10T00:00", End: "2026-11-12T00:00", Silence: models.Silence{Matchers: []models.Matchers{service, env2}}},
+			wantErr: true,
+		},
+		{
+			name:        "Longer than section maximum",
+			shed:        models.Shedule{Cron: "0 0 22 * * *", Until: "06:00", Silence: models.Silence{Matchers: []models.Matchers{service, env2}}},
+			maxDuration: 4 * time.Hour,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section := models.SheduleSection{Shedules: []models.Shedule{tt.shed}, MaxDuration: models.Duration(tt.maxDuration)}
+
+			errs := section.Validate(env)
+			if (len(errs) != 0) != tt.wantErr {
+				t.Errorf("Validate() = %v, wantErr %v", errs, tt.wantErr)
+			}
+
+			if err := section.Shedules[0].CheckGuardrails(env, now); (err != nil) != tt.wantErr {
+				t.Errorf("CheckGuardrails() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package models
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // Matchers type of Alertmanager matchers.
 type Matchers struct {
@@ -10,6 +14,199 @@ type Matchers struct {
 	Value   string `json:"value" yaml:"value"`
 }
 
+// String return matcher in Alertmanager syntax: name="value", name!="value", name=~"regex", name!~"regex".
 func (o Matchers) String() string {
-	return fmt.Sprintf("%#v", o)
+	return o.Name + o.operator() + strconv.Quote(o.Value)
+}
+
+// operator return Alertmanager operator of matcher.
+func (o Matchers) operator() string {
+	switch {
+	case o.IsEqual && o.IsRegex:
+		return "=~"
+	case o.IsRegex:
+		return "!~"
+	case o.IsEqual:
+		return "="
+	default:
+		return "!="
+	}
+}
+
+// MatcherList list of matchers. In YAML it is Alertmanager selector string ('{a="b", c=~"d", e!="f"}'),
+// or list of matchers, each one is map (name, value, isEqual, isRegex) or string in Alertmanager syntax ('a="b"').
+type MatcherList []Matchers
+
+// String return matchers as Alertmanager selector: {a="b", c=~"d"}.
+func (o MatcherList) String() string {
+	parts := make([]string, 0, len(o))
+	for _, matcher := range o {
+		parts = append(parts, matcher.String())
+	}
+
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// UnmarshalYAML parse selector string or list of matchers.
+func (o *MatcherList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var selector string
+
+	if err := unmarshal(&selector); err == nil {
+		list, err := ParseSelector(selector)
+		if err != nil {
+			return err
+		}
+
+		*o = list
+
+		return nil
+	}
+
+	var items []matcherItem
+
+	if err := unmarshal(&items); err != nil {
+		return err
+	}
+
+	*o = make(MatcherList, 0, len(items))
+	for _, item := range items {
+		*o = append(*o, Matchers(item))
+	}
+
+	return nil
+}
+
+// matcherItem item of matchers list in YAML: map or string in Alertmanager syntax.
+type matcherItem Matchers
+
+// UnmarshalYAML parse matcher map or string.
+func (o *matcherItem) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var text string
+
+	if err := unmarshal(&text); err == nil {
+		matcher, err := ParseMatcher(text)
+		*o = matcherItem(matcher)
+
+		return err
+	}
+
+	var matcher struct {
+		IsEqual bool   `yaml:"isEqual"`
+		IsRegex bool   `yaml:"isRegex"`
+		Name    string `yaml:"name"`
+		Value   string `yaml:"value"`
+	}
+
+	if err := unmarshal(&matcher); err != nil {
+		return err
+	}
+
+	*o = matcherItem(matcher)
+
+	return nil
+}
+
+// ParseSelector parse Alertmanager selector: {a="b", c=~"d", e!="f"}. Braces are optional.
+func ParseSelector(text string) (MatcherList, error) {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "{") {
+		if !strings.HasSuffix(text, "}") {
+			return nil, fmt.Errorf("invalid selector %q: no closing brace", text)
+		}
+
+		text = text[1 : len(text)-1]
+	}
+
+	var (
+		result MatcherList
+		quoted bool
+		start  int
+	)
+
+	for i := 0; i <= len(text); i++ {
+		if i < len(text) {
+			switch text[i] {
+			case '\\':
+				if quoted {
+					i++
+				}
+
+				continue
+			case '"':
+				quoted = !quoted
+				continue
+			case ',':
+				if quoted {
+					continue
+				}
+			default:
+				continue
+			}
+		} else if quoted {
+			return nil, fmt.Errorf("invalid selector %q: unterminated quote", text)
+		}
+
+		part := strings.TrimSpace(text[start:i])
+		start = i + 1
+
+		if part == "" {
+			continue
+		}
+
+		matcher, err := ParseMatcher(part)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, matcher)
+	}
+
+	return result, nil
+}
+
+// ParseMatcher parse one matcher in Alertmanager syntax: name="value", name!="value", name=~"regex", name!~"regex".
+// Quotes of value are optional.
+func ParseMatcher(text string) (Matchers, error) {
+	var result Matchers
+
+	text = strings.TrimSpace(text)
+
+	i := strings.IndexAny(text, "=!")
+	if i <= 0 {
+		return result, fmt.Errorf("invalid matcher %q", text)
+	}
+
+	result.Name = strings.TrimSpace(text[:i])
+	rest := text[i:]
+
+	switch {
+	case strings.HasPrefix(rest, "=~"):
+		result.IsEqual, result.IsRegex = true, true
+		rest = rest[2:]
+	case strings.HasPrefix(rest, "!~"):
+		result.IsRegex = true
+		rest = rest[2:]
+	case strings.HasPrefix(rest, "!="):
+		rest = rest[2:]
+	case strings.HasPrefix(rest, "="):
+		result.IsEqual = true
+		rest = rest[1:]
+	default:
+		return result, fmt.Errorf("invalid operator in matcher %q", text)
+	}
+
+	rest = strings.TrimSpace(rest)
+
+	if strings.HasPrefix(rest, `"`) {
+		value, err := strconv.Unquote(rest)
+		if err != nil {
+			return result, fmt.Errorf("invalid value in matcher %q: %w", text, err)
+		}
+
+		rest = value
+	}
+
+	result.Value = rest
+
+	return result, nil
 }
@@ -0,0 +1,101 @@
+package models_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"gopkg.in/yaml.v2"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    models.MatcherList
+		wantErr bool
+	}{
+		{
+			name: "All operators",
+			text: `{a="b", c=~"d.*", e!="f", g!~"h|i"}`,
+			want: models.MatcherList{
+				{IsEqual: true, Name: "a", Value: "b"},
+				{IsEqual: true, IsRegex: true, Name: "c", Value: "d.*"},
+				{Name: "e", Value: "f"},
+				{IsRegex: true, Name: "g", Value: "h|i"},
+			},
+		},
+		{
+			name: "Without braces and quotes",
+			text: `service=db, env != prod`,
+			want: models.MatcherList{
+				{IsEqual: true, Name: "service", Value: "db"},
+				{Name: "env", Value: "prod"},
+			},
+		},
+		{
+			name: "Comma and escaped quote in value",
+			text: `{comment="a, \"b\"",}`,
+			want: models.MatcherList{{IsEqual: true, Name: "comment", Value: `a, "b"`}},
+		},
+		{name: "Empty", text: "{}"},
+		{name: "No closing brace", text: `{a="b"`, wantErr: true},
+		{name: "Unterminated quote", text: `{a="b}`, wantErr: true},
+		{name: "No operator", text: `{a}`, wantErr: true},
+		{name: "No name", text: `{="b"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := models.ParseSelector(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSelector() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSelector() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatcherList_String(t *testing.T) {
+	text := `{a="b", c=~"d.*", e!="f \"g\"", h!~"i"}`
+
+	list, err := models.ParseSelector(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := list.String(); got != text {
+		t.Errorf("String() = %v, want %v", got, text)
+	}
+}
+
+func TestMatcherList_UnmarshalYAML(t *testing.T) {
+	section := `globalmatchers: '{env="prod"}'
+shedules:
+  - cron: '0 0 1 * * *'
+    silence:
+      matchers:
+        - 'service=~"db.*"'
+        - {isEqual: false, isRegex: false, name: severity, value: info}
+`
+
+	var got models.SheduleSection
+	if err := yaml.Unmarshal([]byte(section), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if want := `{env="prod"}`; got.GlobalMatchers.String() != want {
+		t.Errorf("GlobalMatchers = %v, want %v", got.GlobalMatchers, want)
+	}
+
+	if want := `{service=~"db.*", severity!="info"}`; got.Shedules[0].Silence.Matchers.String() != want {
+		t.Errorf("Matchers = %v, want %v", got.Shedules[0].Silence.Matchers, want)
+	}
+
+	if err := yaml.Unmarshal([]byte(`globalmatchers: '{env}'`), &got); err == nil {
+		t.Error("Unmarshal() of invalid selector: no error")
+	}
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// onceSchedule cron schedule activated only once, at start.
+type onceSchedule struct {
+	start time.Time
+}
+
+// Next return start if it is after t, else zero time.
+func (o onceSchedule) Next(t time.Time) time.Time {
+	if o.start.After(t) {
+		return o.start
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,183 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxOverlapRanges limit of reported time ranges for one pair of shedules.
+const maxOverlapRanges = 3
+
+// Overlap of silence windows of two shedules with identical or subsuming matchers,
+// or of consecutive windows of one shedule with duration longer than its period (stacking).
+type Overlap struct {
+	First    *Shedule
+	Second   *Shedule // Same as First for stacking.
+	Stacking bool
+	Ranges   [][2]time.Time // Overlapped time ranges, first maxOverlapRanges.
+	Count    int            // Count of overlapped ranges in checked horizon.
+}
+
+// String describe overlap.
+func (o Overlap) String() string {
+	ranges := ""
+	for _, rng := range o.Ranges {
+		ranges += fmt.Sprintf(" [%v - %v]", rng[0].Format(time.RFC3339), rng[1].Format(time.RFC3339))
+	}
+
+	if o.Stacking {
+		return fmt.Sprintf("windows of shedule %v stack, duration is longer than period: %v times, first%v",
+			o.First.describe(), o.Count, ranges)
+	}
+
+	return fmt.Sprintf("shedules %v and %v overlap with subsuming matchers: %v times, first%v",
+		o.First.describe(), o.Second.describe(), o.Count, ranges)
+}
+
+// describe return shedule with section for messages.
+func (o *Shedule) describe() string {
+	name := ""
+	if o.section != nil {
+		name = o.section.GetSectionName()
+	}
+
+	return fmt.Sprintf("%v [%v] %v", name, o.Spec(), o.matchers())
+}
+
+// FindOverlaps find overlapping windows of shedules of sections from from to to.
+// Shedules overlap, if they have same tenant and sink, matchers of one are subset of matchers of other, and windows intersect.
+func FindOverlaps(env *Environment, sections []*SheduleSection, from, to time.Time) []Overlap {
+	var (
+		result  []Overlap
+		sheds   []*Shedule
+		windows [][]Window
+	)
+
+	for _, section := range sections {
+		for key := range section.Shedules {
+			shed := &section.Shedules[key]
+			if shed.section != section { // runned sections are read concurrently, their shedules already have section.
+				shed.section = section
+			}
+
+			sheds = append(sheds, shed)
+			windows = append(windows, shed.Upcoming(env, from, to, 0))
+		}
+	}
+
+	for i := range sheds {
+		if overlap, ok := stacking(sheds[i], windows[i]); ok {
+			result = append(result, overlap)
+		}
+
+		for j := i + 1; j < len(sheds); j++ {
+			if !sameTarget(sheds[i], sheds[j]) {
+				continue
+			}
+
+			first, second := sheds[i].matchers(), sheds[j].matchers()
+			if !subset(first, second) && !subset(second, first) {
+				continue
+			}
+
+			if overlap, ok := intersect(windows[i], windows[j]); ok {
+				overlap.First, overlap.Second = sheds[i], sheds[j]
+				result = append(result, overlap)
+			}
+		}
+	}
+
+	return result
+}
+
+// stacking find consecutive windows of shedule, which intersect.
+func stacking(shed *Shedule, windows []Window) (Overlap, bool) {
+	result := Overlap{First: shed, Second: shed, Stacking: true}
+
+	for i := 1; i < len(windows); i++ {
+		if windows[i].Start.Before(windows[i-1].End) {
+			result.add(windows[i].Start, minTime(windows[i-1].End, windows[i].End))
+		}
+	}
+
+	return result, result.Count > 0
+}
+
+// intersect find intersections of two lists of windows, sorted by start.
+func intersect(first, second []Window) (Overlap, bool) {
+	var (
+		result Overlap
+		i, j   int
+	)
+
+	for i < len(first) && j < len(second) {
+		start := maxTime(first[i].Start, second[j].Start)
+		end := minTime(first[i].End, second[j].End)
+
+		if start.Before(end) {
+			result.add(start, end)
+		}
+
+		if first[i].End.Before(second[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return result, result.Count > 0
+}
+
+// add overlapped range.
+func (o *Overlap) add(start, end time.Time) {
+	o.Count++
+
+	if len(o.Ranges) < maxOverlapRanges {
+		o.Ranges = append(o.Ranges, [2]time.Time{start, end})
+	}
+}
+
+// sameTarget check if shedules create silences for same tenant in same sink.
+func sameTarget(first, second *Shedule) bool {
+	if first.section == nil || second.section == nil {
+		return first.section == second.section
+	}
+
+	return first.section.Tenant == second.section.Tenant && first.section.GetSinkName() == second.section.GetSinkName()
+}
+
+// subset check if all matchers of first are in second, so silence of first mutes all alerts muted by second.
+func subset(first, second []Matchers) bool {
+	for _, matcher := range first {
+		found := false
+
+		for _, other := range second {
+			if matcher == other {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return len(first) > 0
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,49 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+func TestFindOverlaps(t *testing.T) {
+	db := models.Matchers{IsEqual: true, Name: "service", Value: "db"}
+	prod := models.Matchers{IsEqual: true, Name: "env", Value: "prod"}
+	web := models.Matchers{IsEqual: true, Name: "service", Value: "web"}
+	stage := models.Matchers{IsEqual: true, Name: "env", Value: "stage"}
+
+	yaml := &models.SheduleSection{Shedules: []models.Shedule{
+		{Cron: "0 0 22 * * *", Until: "06:00", Silence: models.Silence{Matchers: []models.Matchers{db}}},
+		{Cron: "0 0 * * * *", Duration: models.Duration(90 * time.Minute), Silence: models.Silence{Matchers: []models.Matchers{web}}},
+	}}
+	csv := &models.SheduleSection{Shedules: []models.Shedule{
+		{Cron: "0 0 2 * * *", Duration: models.Duration(3 * time.Hour), Silence: models.Silence{Matchers: []models.Matchers{prod, db}}},
+		{Cron: "0 0 3 * * *", Duration: models.Duration(time.Hour), Silence: models.Silence{Matchers: []models.Matchers{stage}}},
+	}}
+	tenant := &models.SheduleSection{Tenant: "team-b", Shedules: []models.Shedule{
+		{Cron: "0 0 2 * * *", Duration: models.Duration(time.Hour), Silence: models.Silence{Matchers: []models.Matchers{db}}},
+	}}
+
+	from := time.Date(2026, 11, 3, 12, 0, 0, 0, time.Local)
+	overlaps := models.FindOverlaps(nil, []*models.SheduleSection{yaml, csv, tenant}, from, from.AddDate(0, 0, 2))
+
+	if len(overlaps) != 2 {
+		t.Fatalf("FindOverlaps() = %v, want 2", overlaps)
+	}
+
+	overlap, stacking := overlaps[0], overlaps[1]
+
+	if !stacking.Stacking || stacking.First != &yaml.Shedules[1] || stacking.Count == 0 {
+		t.Errorf("FindOverlaps() stacking = %v", stacking)
+	}
+
+	if overlap.Stacking || overlap.First != &yaml.Shedules[0] || overlap.Second != &csv.Shedules[0] || overlap.Count != 2 {
+		t.Fatalf("FindOverlaps() overlap = %v", overlap)
+	}
+
+	want := [2]time.Time{time.Date(2026, 11, 4, 2, 0, 0, 0, time.Local), time.Date(2026, 11, 4, 5, 0, 0, 0, time.Local)}
+	if !overlap.Ranges[0][0].Equal(want[0]) || !overlap.Ranges[0][1].Equal(want[1]) {
+		t.Errorf("FindOverlaps() range = %v, want %v", overlap.Ranges[0], want)
+	}
+}
@@ -0,0 +1,192 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PauseKind kind of paused target.
+type PauseKind string
+
+const (
+	// PauseSection pause of all shedules of section, target is section name.
+	PauseSection PauseKind = "section"
+	// PauseShedule pause of one shedule, target is shedule ID.
+	PauseShedule PauseKind = "shedule"
+)
+
+// Pause of section or shedule at runtime.
+type Pause struct {
+	Kind   PauseKind `json:"kind"`
+	Target string    `json:"target"`          // Section name or shedule ID.
+	Since  time.Time `json:"since"`           // Time of pause.
+	Until  time.Time `json:"until,omitempty"` // Time of auto-resume, zero for pause until resume.
+	By     string    `json:"by"`              // User paused target.
+	Reason string    `json:"reason,omitempty"`
+}
+
+// Active check if pause is active at now.
+func (o Pause) Active(now time.Time) bool {
+	return o.Until.IsZero() || now.Before(o.Until)
+}
+
+// Pauses registry of pauses, saved in file for restarts.
+type Pauses struct {
+	mux   sync.Mutex
+	path  string
+	items map[string]Pause
+}
+
+// NewPauses return registry of pauses, loaded from file path. Empty path for registry without persistence.
+func NewPauses(path string) (*Pauses, error) {
+	result := &Pauses{path: path, items: make(map[string]Pause)}
+
+	if path == "" {
+		return result, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return result, nil
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	var list []Pause
+
+	if err := json.Unmarshal(data, &list); err != nil {
+		return result, err
+	}
+
+	for _, pause := range list {
+		result.items[pauseKey(pause.Kind, pause.Target)] = pause
+	}
+
+	return result, nil
+}
+
+// Get return pause of target active at now.
+func (o *Pauses) Get(kind PauseKind, target string, now time.Time) (Pause, bool) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	pause, ok := o.items[pauseKey(kind, target)]
+
+	return pause, ok && pause.Active(now)
+}
+
+// Set add or replace pause and save registry.
+func (o *Pauses) Set(pause Pause) error {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	o.items[pauseKey(pause.Kind, pause.Target)] = pause
+
+	return o.save()
+}
+
+// Remove pause of target and save registry. Return false if target was not paused.
+func (o *Pauses) Remove(kind PauseKind, target string) (bool, error) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	if _, ok := o.items[pauseKey(kind, target)]; !ok {
+		return false, nil
+	}
+
+	delete(o.items, pauseKey(kind, target))
+
+	return true, o.save()
+}
+
+// Expire remove pauses ended before now and save registry, return removed pauses.
+func (o *Pauses) Expire(now time.Time) ([]Pause, error) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	var result []Pause
+
+	for key, pause := range o.items {
+		if !pause.Active(now) {
+			result = append(result, pause)
+			delete(o.items, key)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return result, o.save()
+}
+
+// List return pauses active at now, sorted by kind and target.
+func (o *Pauses) List(now time.Time) []Pause {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	result := make([]Pause, 0, len(o.items))
+
+	for _, pause := range o.items {
+		if pause.Active(now) {
+			result = append(result, pause)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return pauseKey(result[i].Kind, result[i].Target) < pauseKey(result[j].Kind, result[j].Target)
+	})
+
+	return result
+}
+
+// save write pauses to file through temporary file, must be called with locked mux.
+func (o *Pauses) save() error {
+	if o.path == "" {
+		return nil
+	}
+
+	list := make([]Pause, 0, len(o.items))
+	for _, pause := range o.items {
+		list = append(list, pause)
+	}
+
+	return saveJSON(o.path, list)
+}
+
+// saveJSON write value as JSON to file path through temporary file.
+func saveJSON(path string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func pauseKey(kind PauseKind, target string) string {
+	return string(kind) + "|" + target
+}
@@ -0,0 +1,78 @@
+package models_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+func TestPauses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pauses.json")
+	now := time.Date(2026, 11, 3, 12, 0, 0, 0, time.UTC)
+
+	pauses, err := models.NewPauses(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pauses.Set(models.Pause{Kind: models.PauseSection, Target: "backup.yaml", Since: now, By: "ops"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pauses.Set(models.Pause{Kind: models.PauseShedule, Target: "0a1b2c", Since: now, Until: now.Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	// reload after restart.
+	pauses, err = models.NewPauses(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pause, ok := pauses.Get(models.PauseSection, "backup.yaml", now); !ok || pause.By != "ops" {
+		t.Errorf("Get() section pause = %v, %v", pause, ok)
+	}
+
+	if _, ok := pauses.Get(models.PauseShedule, "0a1b2c", now.Add(time.Hour)); ok {
+		t.Errorf("Get() returned pause after auto-resume time")
+	}
+
+	expired, err := pauses.Expire(now.Add(2 * time.Hour))
+	if err != nil || len(expired) != 1 || expired[0].Target != "0a1b2c" {
+		t.Errorf("Expire() = %v, %v", expired, err)
+	}
+
+	if ok, err := pauses.Remove(models.PauseSection, "backup.yaml"); !ok || err != nil {
+		t.Errorf("Remove() = %v, %v", ok, err)
+	}
+
+	if list := pauses.List(now); len(list) != 0 {
+		t.Errorf("List() = %v, want empty", list)
+	}
+}
+
+func TestShedule_Upcoming_disabled(t *testing.T) {
+	disabled := false
+	now := time.Date(2026, 11, 3, 12, 0, 0, 0, time.Local)
+
+	section := models.SheduleSection{Shedules: []models.Shedule{
+		{Cron: "0 0 22 * * *", Duration: models.Duration(time.Hour), Enabled: &disabled},
+		{Cron: "0 0 23 * * *", Duration: models.Duration(time.Hour)},
+	}}
+	section.SetSectionName("night.yaml")
+
+	pauses, _ := models.NewPauses("")
+	env := &models.Environment{Pauses: pauses}
+
+	if windows := section.Upcoming(env, now, now.AddDate(0, 0, 1), 0); len(windows) != 1 || windows[0].Start.Hour() != 23 {
+		t.Errorf("Upcoming() = %v, want only enabled shedule", windows)
+	}
+
+	_ = pauses.Set(models.Pause{Kind: models.PauseSection, Target: "night.yaml", Until: now.Add(24 * time.Hour)})
+
+	if windows := section.Upcoming(env, now, now.AddDate(0, 0, 2), 0); len(windows) != 1 || windows[0].Start.Day() != 4 {
+		t.Errorf("Upcoming() = %v, want windows after auto-resume", windows)
+	}
+}
@@ -0,0 +1,146 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Policy process-wide rules for all silences: mandatory matchers appended to every silence
+// and forbidden matcher patterns, checked before silence is sent to any sink.
+type Policy struct {
+	mux   sync.RWMutex
+	path  string
+	rules PolicyRules
+}
+
+// PolicyRules content of policy file.
+type PolicyRules struct {
+	Mandatory  MatcherList        `yaml:"mandatory"`  // Matchers appended to every silence.
+	Forbidden  []ForbiddenMatcher `yaml:"forbidden"`  // Silences with matching matchers are rejected.
+	Guardrails Guardrails         `yaml:"guardrails"` // Limits of silences, shedules exceeding them are refused.
+}
+
+// ForbiddenMatcher pattern of forbidden matcher. Name and Value are regexps, anchored to whole name and value.
+type ForbiddenMatcher struct {
+	Name    string `yaml:"name"`    // Regexp of label name, ""=any.
+	Value   string `yaml:"value"`   // Regexp of matcher value, ""=any.
+	IsEqual *bool  `yaml:"isEqual"` // Match only equal (true) or not equal (false) matchers, nil=any.
+	IsRegex *bool  `yaml:"isRegex"` // Match only regex (true) or plain (false) matchers, nil=any.
+	Reason  string `yaml:"reason"`  // Reason reported on violation.
+	name    *regexp.Regexp
+	value   *regexp.Regexp
+}
+
+// NewPolicy return policy loaded from file path. Empty path for policy without rules.
+func NewPolicy(path string) (*Policy, error) {
+	result := &Policy{path: path}
+
+	return result, result.Reload()
+}
+
+// Reload read policy file again, on error current rules stay.
+func (o *Policy) Reload() error {
+	if o.path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(o.path)
+	if err != nil {
+		return err
+	}
+
+	var rules PolicyRules
+
+	if err := yaml.UnmarshalStrict(data, &rules); err != nil {
+		return fmt.Errorf("decode policy %v: %w", o.path, err)
+	}
+
+	for i := range rules.Forbidden {
+		if err := rules.Forbidden[i].compile(); err != nil {
+			return fmt.Errorf("policy %v: forbidden matcher %v: %w", o.path, i, err)
+		}
+	}
+
+	if err := rules.Guardrails.compile(); err != nil {
+		return fmt.Errorf("policy %v: guardrails: %w", o.path, err)
+	}
+
+	o.mux.Lock()
+	o.rules = rules
+	o.mux.Unlock()
+
+	return nil
+}
+
+// Apply append mandatory matchers to silence and check forbidden matchers.
+// Return error, if silence has forbidden matcher and must not be sent.
+func (o *Policy) Apply(silence *Silence) error {
+	if o == nil {
+		return nil
+	}
+
+	o.mux.RLock()
+	defer o.mux.RUnlock()
+
+	for _, mandatory := range o.rules.Mandatory {
+		if !hasMatcher(silence.Matchers, mandatory) {
+			silence.Matchers = append(silence.Matchers, mandatory)
+		}
+	}
+
+	for _, matcher := range silence.Matchers {
+		for _, forbidden := range o.rules.Forbidden {
+			if forbidden.match(matcher) {
+				return fmt.Errorf("matcher %v forbidden by policy: %v", matcher, forbidden.Reason)
+			}
+		}
+	}
+
+	return nil
+}
+
+// compile regexps of pattern.
+func (o *ForbiddenMatcher) compile() (err error) {
+	if o.Name != "" {
+		if o.name, err = regexp.Compile("^(?:" + o.Name + ")$"); err != nil {
+			return err
+		}
+	}
+
+	if o.Value != "" {
+		o.value, err = regexp.Compile("^(?:" + o.Value + ")$")
+	}
+
+	return err
+}
+
+// match check if matcher match pattern.
+func (o ForbiddenMatcher) match(matcher Matchers) bool {
+	switch {
+	case o.IsEqual != nil && *o.IsEqual != matcher.IsEqual:
+		return false
+	case o.IsRegex != nil && *o.IsRegex != matcher.IsRegex:
+		return false
+	case o.name != nil && !o.name.MatchString(matcher.Name):
+		return false
+	case o.value != nil && !o.value.MatchString(matcher.Value):
+		return false
+	}
+
+	return true
+}
+
+// hasMatcher check if matchers contain matcher.
+func hasMatcher(matchers []Matchers, matcher Matchers) bool {
+	for _, m := range matchers {
+		if m == matcher {
+			return true
+		}
+	}
+
+	return false
+}
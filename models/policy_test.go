@@ -0,0 +1,91 @@
+package models_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+func TestPolicy_Apply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	policy := `mandatory:
+  - {isEqual: false, isRegex: false, name: severity, value: critical}
+forbidden:
+  - {name: service, value: 'payment.*', reason: payment systems}
+  - {value: '\.\*', isRegex: true, reason: catch-all regex}
+`
+
+	if err := os.WriteFile(path, []byte(policy), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := models.NewPolicy(path)
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+
+	severity := models.Matchers{IsEqual: false, Name: "severity", Value: "critical"}
+
+	tests := []struct {
+		name     string
+		matchers []models.Matchers
+		want     int
+		wantErr  bool
+	}{
+		{
+			name:     "Mandatory appended",
+			matchers: []models.Matchers{{IsEqual: true, Name: "service", Value: "db"}},
+			want:     2,
+		},
+		{
+			name:     "Mandatory not duplicated",
+			matchers: []models.Matchers{{IsEqual: true, Name: "service", Value: "db"}, severity},
+			want:     2,
+		},
+		{
+			name:     "Forbidden value",
+			matchers: []models.Matchers{{IsEqual: true, Name: "service", Value: "payment-gw"}},
+			wantErr:  true,
+		},
+		{
+			name:     "Forbidden catch-all",
+			matchers: []models.Matchers{{IsEqual: true, IsRegex: true, Name: "alertname", Value: ".*"}},
+			wantErr:  true,
+		},
+		{
+			name:     "Plain .* value allowed",
+			matchers: []models.Matchers{{IsEqual: true, Name: "alertname", Value: ".*"}},
+			want:     2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			silence := models.Silence{Matchers: tt.matchers}
+
+			err := p.Apply(&silence)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && len(silence.Matchers) != tt.want {
+				t.Errorf("Apply() matchers = %v, want %v", silence.Matchers, tt.want)
+			}
+		})
+	}
+
+	if err := os.WriteFile(path, []byte("forbidden: [{name: '('}]"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.Reload(); err == nil {
+		t.Errorf("Reload() of invalid policy error = nil")
+	}
+
+	silence := models.Silence{Matchers: []models.Matchers{{IsEqual: true, Name: "service", Value: "payment"}}}
+	if err := p.Apply(&silence); err == nil {
+		t.Errorf("Apply() after failed Reload() must keep previous rules")
+	}
+}
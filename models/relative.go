@@ -0,0 +1,154 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Volkov-Stanislav/cron"
+)
+
+// Prefixes of schedule expressions relative to Nth weekday of month.
+const (
+	nthPrefix          = "@nth"          // @nth <1-5|last> <weekday> [+-days] <hh:mm[:ss]>
+	patchTuesdayPrefix = "@patchtuesday" // @patchtuesday [+-days] <hh:mm[:ss]>, same as @nth 2 tue.
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// relativeSchedule activated at time of day in N days after Nth weekday of every month.
+type relativeSchedule struct {
+	nth     int // Number of weekday in month, 1-5, -1 for last weekday.
+	weekday time.Weekday
+	days    int // Offset in days from Nth weekday, may be negative.
+	hour    int
+	minute  int
+	second  int
+	loc     *time.Location
+}
+
+// ParseSchedule parse cron expression with seconds or relative expression (@nth, @patchtuesday),
+// relative expressions use time zone loc.
+func ParseSchedule(spec string, loc *time.Location) (cron.Schedule, error) {
+	fields := strings.Fields(strings.ToLower(spec))
+
+	if len(fields) == 0 || (fields[0] != nthPrefix && fields[0] != patchTuesdayPrefix) {
+		return cronParser.Parse(spec)
+	}
+
+	schedule := relativeSchedule{nth: 2, weekday: time.Tuesday, loc: loc}
+	args := fields[1:]
+
+	if fields[0] == nthPrefix {
+		if len(args) < 3 {
+			return nil, fmt.Errorf("invalid %v expression %q, want: @nth <1-5|last> <weekday> [+-days] <hh:mm>", nthPrefix, spec)
+		}
+
+		if args[0] == "last" {
+			schedule.nth = -1
+		} else if n, err := strconv.Atoi(args[0]); err == nil && n >= 1 && n <= 5 {
+			schedule.nth = n
+		} else {
+			return nil, fmt.Errorf("invalid week number %q in %q", args[0], spec)
+		}
+
+		weekday, ok := weekdays[args[1]]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q in %q", args[1], spec)
+		}
+
+		schedule.weekday = weekday
+		args = args[2:]
+	}
+
+	if len(args) == 2 {
+		days, err := strconv.Atoi(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid days offset %q in %q", args[0], spec)
+		}
+
+		schedule.days = days
+		args = args[1:]
+	}
+
+	if len(args) != 1 {
+		return nil, fmt.Errorf("invalid relative expression %q", spec)
+	}
+
+	if err := schedule.setTime(args[0]); err != nil {
+		return nil, fmt.Errorf("invalid time in %q: %w", spec, err)
+	}
+
+	return schedule, nil
+}
+
+// setTime parse time of day hh:mm[:ss].
+func (o *relativeSchedule) setTime(value string) error {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return fmt.Errorf("want hh:mm[:ss], got %q", value)
+	}
+
+	limits := []int{23, 59, 59}
+	fields := []*int{&o.hour, &o.minute, &o.second}
+
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || n > limits[i] {
+			return fmt.Errorf("want hh:mm[:ss], got %q", value)
+		}
+
+		*fields[i] = n
+	}
+
+	return nil
+}
+
+// Next return first activation after t.
+func (o relativeSchedule) Next(t time.Time) time.Time {
+	local := t.In(o.loc)
+
+	// Offset may move activation to next or previous months, so start from month, which activation is surely before t.
+	months := abs(o.days)/28 + 2
+
+	for i := -months; i < 14+months; i++ {
+		base, ok := nthWeekday(local.Year(), local.Month()+time.Month(i), o.nth, o.weekday, o.loc)
+		if !ok {
+			continue
+		}
+
+		next := time.Date(base.Year(), base.Month(), base.Day()+o.days, o.hour, o.minute, o.second, 0, o.loc)
+		if next.After(t) {
+			return next.In(t.Location())
+		}
+	}
+
+	return time.Time{}
+}
+
+// abs return absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+// nthWeekday return date of Nth (-1 for last) weekday of month, false if month has no such weekday.
+func nthWeekday(year int, month time.Month, nth int, weekday time.Weekday, loc *time.Location) (time.Time, bool) {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+
+	if nth < 0 {
+		last := first.AddDate(0, 1, -1)
+		return last.AddDate(0, 0, -((int(last.Weekday()) - int(weekday) + 7) % 7)), true
+	}
+
+	day := first.AddDate(0, 0, (int(weekday)-int(first.Weekday())+7)%7+(nth-1)*7)
+
+	return day, day.Month() == first.Month()
+}
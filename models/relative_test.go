@@ -0,0 +1,103 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+func TestParseSchedule_Relative(t *testing.T) {
+	loc := time.FixedZone("UTC3", 3*60*60)
+	from := time.Date(2026, 11, 1, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		name string
+		spec string
+		want []time.Time
+	}{
+		{
+			name: "Patch Tuesday plus 5 days crosses week boundary",
+			spec: "@patchtuesday +5 02:00",
+			want: []time.Time{
+				time.Date(2026, 11, 15, 2, 0, 0, 0, loc),
+				time.Date(2026, 12, 13, 2, 0, 0, 0, loc),
+				time.Date(2027, 1, 17, 2, 0, 0, 0, loc),
+			},
+		},
+		{
+			name: "Patch Tuesday plus 60 days from month before previous",
+			spec: "@patchtuesday +60 02:00",
+			want: []time.Time{
+				time.Date(2026, 11, 7, 2, 0, 0, 0, loc),
+				time.Date(2026, 12, 12, 2, 0, 0, 0, loc),
+				time.Date(2027, 1, 9, 2, 0, 0, 0, loc),
+			},
+		},
+		{
+			name: "Patch Tuesday minus 40 days from next months",
+			spec: "@patchtuesday -40 02:00",
+			want: []time.Time{
+				time.Date(2026, 12, 3, 2, 0, 0, 0, loc),
+				time.Date(2026, 12, 31, 2, 0, 0, 0, loc),
+			},
+		},
+		{
+			name: "Nth weekday",
+			spec: "@nth 1 thu 02:30:15",
+			want: []time.Time{
+				time.Date(2026, 11, 5, 2, 30, 15, 0, loc),
+				time.Date(2026, 12, 3, 2, 30, 15, 0, loc),
+			},
+		},
+		{
+			name: "Last friday minus day",
+			spec: "@nth last fri -1 23:00",
+			want: []time.Time{
+				time.Date(2026, 11, 26, 23, 0, 0, 0, loc),
+				time.Date(2026, 12, 24, 23, 0, 0, 0, loc),
+			},
+		},
+		{
+			name: "Fifth weekday skip months without it",
+			spec: "@nth 5 mon 01:00",
+			want: []time.Time{
+				time.Date(2026, 11, 30, 1, 0, 0, 0, loc),
+				time.Date(2027, 3, 29, 1, 0, 0, 0, loc),
+			},
+		},
+		{
+			name: "Cron expression",
+			spec: "0 50 1 * * *",
+			want: []time.Time{
+				time.Date(2026, 11, 1, 1, 50, 0, 0, loc),
+				time.Date(2026, 11, 2, 1, 50, 0, 0, loc),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := models.ParseSchedule(tt.spec, loc)
+			if err != nil {
+				t.Fatalf("ParseSchedule() error = %v", err)
+			}
+
+			next := from
+			for _, want := range tt.want {
+				next = schedule.Next(next)
+				if !next.Equal(want) {
+					t.Fatalf("Next() = %v, want %v", next, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, spec := range []string{"@nth 6 tue 02:00", "@nth 2 xyz 02:00", "@patchtuesday +x 02:00", "@patchtuesday 25:00", "@nth 2 tue"} {
+		if _, err := models.ParseSchedule(spec, time.UTC); err == nil {
+			t.Errorf("ParseSchedule(%q) error = nil, want error", spec)
+		}
+	}
+}
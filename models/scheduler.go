@@ -0,0 +1,51 @@
+package models
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Volkov-Stanislav/cron"
+)
+
+// Scheduler activate jobs of shedules of all sections, every entry has own time zone.
+type Scheduler interface {
+	// Add entry activating job by schedule in time zone loc, return ID of entry.
+	Add(schedule cron.Schedule, loc *time.Location, job func()) cron.EntryID
+	// Remove entry, running job is not interrupted.
+	Remove(id cron.EntryID)
+	// Next return next activation of entry, zero time for removed entry or entry without next activation.
+	Next(id cron.EntryID) time.Time
+}
+
+// sectionJobs running jobs of section, new jobs are not started after stop.
+type sectionJobs struct {
+	mux     sync.Mutex
+	stopped bool
+	running sync.WaitGroup
+}
+
+// run job, if section is not stopped. Return false, if job is not started.
+func (o *sectionJobs) run(job func()) bool {
+	o.mux.Lock()
+	if o.stopped {
+		o.mux.Unlock()
+		return false
+	}
+
+	o.running.Add(1)
+	o.mux.Unlock()
+
+	defer o.running.Done()
+
+	job()
+
+	return true
+}
+
+// stop starting of new jobs.
+func (o *sectionJobs) stop() {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	o.stopped = true
+}
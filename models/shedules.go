@@ -1,99 +1,518 @@
 package models
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"encoding/hex"
 	"fmt"
-	"io"
-	"net/http"
 	"time"
 
 	"github.com/Volkov-Stanislav/cron"
-	"github.com/Volkov-Stanislav/silences-sheduler/metrics"
-	"github.com/Volkov-Stanislav/silences-sheduler/stats"
-	"go.uber.org/zap"
+	"github.com/Volkov-Stanislav/silences-sheduler/utils"
+	"github.com/datadog/mmh3"
 )
 
+// sinkTimeout timeout of silence creation in sink.
+const sinkTimeout = 30 * time.Second
+
 // Shedule define cron task for silence.
 type Shedule struct {
-	Cron     string       `yaml:"cron"`     // Crontab defaining time to start silence.
-	Duration int          `yaml:"duration"` // Duration of silence in seconds.
-	Silence  Silence      `yaml:"silence"`  // Silence define.
-	entryID  cron.EntryID // ID of cron task.
+	Cron       string            `yaml:"cron"`       // Crontab defaining time to start silence, or @nth/@patchtuesday expression.
+	Duration   Duration          `yaml:"duration"`   // Duration of silence, seconds or Go duration ("3h30m").
+	Until      string            `yaml:"until"`      // End of silence, time of day ("06:00") or cron, instead of Duration.
+	Start      string            `yaml:"start"`      // Start of one-off silence, instead of Cron and Duration.
+	End        string            `yaml:"end"`        // End of one-off silence.
+	ValidFrom  string            `yaml:"validFrom"`  // Date or time since shedule is active. ""=unlimited
+	ValidUntil string            `yaml:"validUntil"` // Date (inclusive) or time until shedule is active. ""=unlimited
+	Exclude    []string          `yaml:"exclude"`    // Names of exclusion calendars.
+	Silence    Silence           `yaml:"silence"`    // Silence define. Comment and CreatedBy may be Go templates.
+	Vars       map[string]string `yaml:"vars"`       // Custom variables for comment and createdBy templates.
+	Hosts      []string          `yaml:"hosts"`      // Hosts of shedule for maintenance targets (Zabbix).
+	Enabled    *bool             `yaml:"enabled"`    // Shedule creates silences. nil=true
+	entryID    cron.EntryID      // ID of scheduler entry.
+	schedule   cron.Schedule     // Schedule instead of Cron, for storages with own recurrence rules.
+	section    *SheduleSection   // Section of shedule, set on section run.
 }
 
 func (o Shedule) String() string {
-	return fmt.Sprintf("%#v", o)
+	return fmt.Sprintf("[%v] %v %q", o.Spec(), o.matchers(), o.Silence.Comment)
 }
 
 // Run shedule.
-func (o *Shedule) Run(apiURL string, log *zap.Logger, stat *stats.Instance, prom *metrics.Instance) {
-	o.Silence.StartsAt = time.Now().UTC().Add(time.Duration(int64(-10) * int64(time.Minute)))
-	o.Silence.EndsAt = time.Now().UTC().Add(time.Duration(int64(o.Duration) * int64(time.Second)))
+func (o *Shedule) Run(env *Environment) {
+	log := env.Logger
+	now := env.now()
+
+	if state, at, err := o.Status(now); state != StateActive {
+		log.Sugar().Infof("Shedule %v skipped, state %v (%v) %v", o.Spec(), state, at, err)
+		o.skip(env, now, string(state), "")
+
+		return
+	}
+
+	if reason, ok := o.paused(env, now); ok {
+		log.Sugar().Infof("Shedule %v skipped, %v", o.Spec(), reason)
+		o.skip(env, now, reason, "")
+
+		return
+	}
+
+	if name, ok := o.excludedBy(env, now); ok {
+		log.Sugar().Infof("Shedule %v skipped by calendar %v", o.Spec(), name)
+		o.skip(env, now, "calendar "+name, "")
+
+		return
+	}
+
+	// requested skip is taken only by run, which would create silence.
+	if o.frozen(env, now, "") {
+		return
+	}
+
+	if env.Skips != nil {
+		if skip, ok := env.Skips.Take(o.ID()); ok {
+			log.Sugar().Infof("Shedule %v skipped, requested by %v, %v runs to skip left", o.Spec(), skip.By, skip.Count-1)
+			o.skip(env, now, "skip-next", skip.By)
+
+			return
+		}
+	}
+
+	o.send(env, now, "")
+}
+
+// RunNow create silence of shedule immediately, from now for configured Duration (or until),
+// ignoring validity ranges, calendars, pauses and skips. by is user requested run.
+func (o *Shedule) RunNow(env *Environment, by string) {
+	env.Logger.Sugar().Infof("Shedule %v run now by %v", o.Spec(), by)
+	o.post(env, env.now(), by)
+}
+
+// post create silences of window started at now in all sinks of shedule, by is user requested run.
+// Nothing is created while freeze is active.
+func (o *Shedule) post(env *Environment, now time.Time, by string) {
+	if o.frozen(env, now, by) {
+		return
+	}
 
-	_, err := postAPI(apiURL, o.Silence, log, stat)
+	o.send(env, now, by)
+}
+
+// frozen check freeze and record skipped run, if freeze is active.
+func (o *Shedule) frozen(env *Environment, now time.Time, by string) bool {
+	freeze := env.Freeze.State()
+	if freeze.Frozen {
+		env.Logger.Sugar().Infof("Shedule %v skipped, freeze by %v %v: %v", o.Spec(), freeze.Source, freeze.By, freeze.Reason)
+		o.skip(env, now, "freeze", by)
+	}
+
+	return freeze.Frozen
+}
+
+// send create silences of window started at now in all sinks of shedule, by is user requested run.
+func (o *Shedule) send(env *Environment, now time.Time, by string) {
+	log := env.Logger
+
+	end := o.WindowEnd(now)
+	silence := o.Silence
+	silence.Matchers = o.matchers()
+	silence.StartsAt = now.UTC().Add(time.Duration(int64(-10) * int64(time.Minute)))
+	silence.EndsAt = end.UTC()
+	policyErr := env.Policy.Apply(&silence)
+
+	if err := o.render(&silence, now, end); err != nil {
+		log.Sugar().Errorf("Error render silence templates of shedule %v: %v", o.Spec(), err)
+	}
+
+	window := Window{
+		Section: o.section,
+		Shedule: o,
+		Silence: silence,
+		Tenant:  o.tenant(),
+		Start:   now,
+		End:     end,
+		By:      by,
+	}
+
+	if policyErr != nil {
+		log.Sugar().Errorf("Silence of shedule %v rejected: %v", o.Spec(), policyErr)
+		o.skip(env, now, "policy", by)
+		env.notify(EventFailed, "", "", window, policyErr)
+
+		return
+	}
+
+	if err := o.checkWindow(env, window); err != nil {
+		log.Sugar().Errorf("Silence of shedule %v rejected: %v", o.Spec(), err)
+		o.skip(env, now, "guardrail", by)
+		env.notify(EventFailed, "", "", window, err)
+
+		return
+	}
+
+	sinks := o.sinks()
+	if len(sinks) == 0 {
+		log.Sugar().Errorf("No sink for shedule %v", o.Spec())
+		return
+	}
+
+	for _, sink := range sinks {
+		o.create(env, sink, window)
+	}
+}
+
+// create silence of window in sink, or extend silence of shedule, which still active.
+func (o *Shedule) create(env *Environment, sink namedSink, window Window) {
+	ctx, cancel := context.WithTimeout(env.context(), sinkTimeout)
+	defer cancel()
+
+	if active, ok := o.posted(env, sink, window); ok {
+		env.Logger.Sugar().Infof("Silence %v of shedule %v in %v already created up to %v", active.ID, o.Spec(), sink.name, window.End)
+		return
+	}
+
+	if id, ok := o.extend(ctx, env, sink, window); ok {
+		env.Logger.Sugar().Infof("Extended silence %v in %v up to %v", id, sink.name, window.End)
+		return
+	}
+
+	silence := window.Silence
+
+	id, err := sink.sink.Create(ctx, window)
 	if err != nil {
-		log.Sugar().Errorf("Error POST in Alertmanager API:  %v", err)
+		env.Logger.Sugar().Errorf("Error create silence in %v (tenant %q):  %v", sink.name, window.Tenant, err)
+		env.Prom.AddSilencesErrors(window.Tenant, sink.name, 1)
+		env.notify(EventFailed, sink.name, "", window, err)
+
+		return
 	}
 
-	prom.AddSilencesCounter(1)
+	env.setActive(ActiveSilence{Key: o.Key(), Sink: sink.name, ID: id, Window: window})
+
+	env.notify(EventCreated, sink.name, id, window, nil)
+	env.Logger.Sugar().Infof("Created silence %v in %v: %v", id, sink.name, silence)
+	env.Stat.AddSheduleRun(fmt.Sprintf("%v;%v;%v;%v;%v;%s;%v;%v\n",
+		window.Start.UTC(), sink.name, window.Tenant, silence.StartsAt, silence.EndsAt, silence.Comment, silence.Matchers, window.By))
+	env.Prom.AddSilencesCounter(window.Tenant, sink.name, 1)
 }
 
-// GetEntryID return ID for cron task for this shedule.
+// skip record in stats and metrics skipped run of shedule with reason, by is user requested skip.
+func (o *Shedule) skip(env *Environment, now time.Time, reason, by string) {
+	env.Stat.AddSheduleRun(fmt.Sprintf("%v;skipped: %v;%v;;;%s;%v;%v\n",
+		now.UTC(), reason, o.tenant(), o.Silence.Comment, o.matchers(), by))
+	env.Prom.AddSkipped(reason, 1)
+}
+
+// excludedBy return name of exclusion calendar of shedule or its section, which exclude date of now.
+func (o *Shedule) excludedBy(env *Environment, now time.Time) (string, bool) {
+	name, ok, unknown := o.excluding(env, now)
+	for _, calendar := range unknown {
+		env.Logger.Sugar().Errorf("Unknown calendar %v in shedule %v", calendar, o.Spec())
+	}
+
+	return name, ok
+}
+
+// excluding return name of exclusion calendar, which exclude date of now, and names of unknown calendars.
+func (o *Shedule) excluding(env *Environment, now time.Time) (name string, ok bool, unknown []string) {
+	names := o.Exclude
+	if o.section != nil {
+		names = append(append([]string{}, o.section.Exclude...), o.Exclude...)
+	}
+
+	for _, name := range names {
+		calendar, ok := o.calendar(env, name)
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+
+		if calendar.Excludes(now.In(o.location())) {
+			return name, true, unknown
+		}
+	}
+
+	return "", false, unknown
+}
+
+// calendar return calendar by name from section of shedule or from other sections.
+func (o *Shedule) calendar(env *Environment, name string) (*Calendar, bool) {
+	if o.section != nil {
+		if calendar, ok := o.section.Calendars[name]; ok {
+			return calendar, true
+		}
+	}
+
+	if env == nil || env.Calendars == nil {
+		return nil, false
+	}
+
+	return env.Calendars.Get(name)
+}
+
+// posted return active silence of shedule in sink with same end as window, e.g. of one-off window
+// posted before reload of section or restart.
+func (o *Shedule) posted(env *Environment, sink namedSink, window Window) (ActiveSilence, bool) {
+	if env.Active == nil {
+		return ActiveSilence{}, false
+	}
+
+	active, ok := env.Active.Get(o.Key(), sink.name, window.Start)
+
+	return active, ok && active.Window.End.Equal(window.End)
+}
+
+// extend silence of shedule in sink, which still active at start of window.
+// Return false if there is no active silence or sink can't extend it.
+func (o *Shedule) extend(ctx context.Context, env *Environment, sink namedSink, window Window) (string, bool) {
+	extender, ok := sink.sink.(Extender)
+	if !ok || env.Active == nil {
+		return "", false
+	}
+
+	active, ok := env.Active.Get(o.Key(), sink.name, window.Start)
+	if !ok || !window.End.After(active.Window.End) {
+		return "", false
+	}
+
+	// registry saved by previous versions keeps no startsAt of silence.
+	window.Silence.StartsAt = active.Window.Silence.StartsAt
+	if window.Silence.StartsAt.IsZero() {
+		window.Silence.StartsAt = active.Window.Start.UTC()
+	}
+
+	id, err := extender.Extend(ctx, window, active.ID)
+	if err != nil {
+		env.Logger.Sugar().Errorf("Error extend silence %v in %v: %v, creating new one", active.ID, sink.name, err)
+		return "", false
+	}
+
+	env.setActive(ActiveSilence{Key: o.Key(), Sink: sink.name, ID: id, Window: window})
+	env.notify(EventExtended, sink.name, id, window, nil)
+
+	return id, true
+}
+
+// Key return key of shedule, identical for same shedule after reload of section.
+func (o *Shedule) Key() string {
+	name := ""
+	if o.section != nil {
+		name = o.section.GetSectionName()
+	}
+
+	return fmt.Sprintf("%v|%v|%v", name, o.Spec(), o.matchers())
+}
+
+// ID return short ID of shedule for API, identical for same shedule after reload and restart.
+func (o *Shedule) ID() string {
+	text := o.Spec()
+	if o.section != nil {
+		text = o.section.GetSectionName() + "|" + text
+	}
+
+	for _, matcher := range o.matchers() {
+		text += fmt.Sprintf("|%v,%v,%v,%v", matcher.Name, matcher.Value, matcher.IsEqual, matcher.IsRegex)
+	}
+
+	return hex.EncodeToString(mmh3.Hash128([]byte(text)).Bytes()[:6])
+}
+
+// IsEnabled check if shedule and its section are enabled in config.
+func (o *Shedule) IsEnabled() bool {
+	if o.Enabled != nil && !*o.Enabled {
+		return false
+	}
+
+	return o.section == nil || o.section.IsEnabled()
+}
+
+// paused return reason of skip, if shedule disabled in config or paused at runtime.
+func (o *Shedule) paused(env *Environment, now time.Time) (string, bool) {
+	if !o.IsEnabled() {
+		return "disabled", true
+	}
+
+	if env == nil || env.Pauses == nil {
+		return "", false
+	}
+
+	if _, ok := env.Pauses.Get(PauseShedule, o.ID(), now); ok {
+		return "paused", true
+	}
+
+	if o.section != nil {
+		if _, ok := env.Pauses.Get(PauseSection, o.section.GetSectionName(), now); ok {
+			return "paused", true
+		}
+	}
+
+	return "", false
+}
+
+// SetSchedule set schedule of activations instead of Cron expression. Cron is kept as description.
+func (o *Shedule) SetSchedule(schedule cron.Schedule) {
+	o.schedule = schedule
+}
+
+// Schedule return schedule of shedule activations in shedule time zone.
+func (o *Shedule) Schedule() (cron.Schedule, error) {
+	if o.schedule != nil {
+		return o.schedule, nil
+	}
+
+	if o.IsOneOff() {
+		start, _, err := o.OneOffWindow()
+		return onceSchedule{start: start}, err
+	}
+
+	return ParseSchedule(o.Cron, o.location())
+}
+
+// IsOneOff check if shedule is one-off window with absolute start and end.
+func (o *Shedule) IsOneOff() bool {
+	return o.Start != "" || o.End != ""
+}
+
+// Spec return text definition of shedule time: cron or one-off window.
+func (o *Shedule) Spec() string {
+	if o.IsOneOff() {
+		return o.Start + " - " + o.End
+	}
+
+	return o.Cron
+}
+
+// OneOffWindow return start and end of one-off shedule.
+func (o *Shedule) OneOffWindow() (start, end time.Time, err error) {
+	if start, err = utils.ParseTime(o.Start, o.location()); err != nil {
+		return start, end, err
+	}
+
+	if end, err = utils.ParseTime(o.End, o.location()); err != nil {
+		return start, end, err
+	}
+
+	if !end.After(start) {
+		err = fmt.Errorf("end %v of one-off shedule before start %v", o.End, o.Start)
+	}
+
+	return start, end, err
+}
+
+// WindowEnd return end of silence window started at start.
+func (o *Shedule) WindowEnd(start time.Time) time.Time {
+	if o.IsOneOff() {
+		if _, end, err := o.OneOffWindow(); err == nil {
+			return end
+		}
+	}
+
+	if o.Until != "" {
+		end, err := untilEnd(o.Until, start, o.location())
+		if err == nil {
+			return end
+		}
+	}
+
+	return start.Add(time.Duration(o.Duration))
+}
+
+// CheckWindow check definition of shedule window at now: one-off start and end or until.
+func (o *Shedule) CheckWindow(now time.Time) error {
+	if o.IsOneOff() {
+		_, _, err := o.OneOffWindow()
+		return err
+	}
+
+	if o.Until != "" {
+		_, err := untilEnd(o.Until, now, o.location())
+		return err
+	}
+
+	return nil
+}
+
+// location return time zone of shedule section.
+func (o *Shedule) location() *time.Location {
+	if o.section == nil {
+		return time.Local
+	}
+
+	return utils.GetLocation(o.section.TimeOffset)
+}
+
+// GetEntryID return ID of scheduler entry of this shedule.
 func (o *Shedule) GetEntryID() cron.EntryID {
 	return o.entryID
 }
 
-// SetEntryID set ID for cron task for this shedule.
+// SetEntryID set ID of scheduler entry of this shedule.
 func (o *Shedule) SetEntryID(id cron.EntryID) {
 	o.entryID = id
 }
 
-func postAPI(url string, data Silence, logger *zap.Logger, stat *stats.Instance) (SilenceID, error) {
-	var result SilenceID
-
-	ctx := context.Background()
-	timeout := 30 * time.Second
-	reqContext, cancel := context.WithTimeout(ctx, timeout)
+// CheckTemplates check syntax of comment and createdBy templates.
+func (o *Shedule) CheckTemplates() error {
+	if err := checkTemplate("comment", o.Silence.Comment); err != nil {
+		return err
+	}
 
-	defer cancel()
+	return checkTemplate("createdBy", o.Silence.CreatedBy)
+}
 
-	b, err := json.Marshal(data)
-	if err != nil {
-		return result, err
+// tenant return Alertmanager tenant of shedule section.
+func (o *Shedule) tenant() string {
+	if o.section == nil {
+		return ""
 	}
 
-	byteReader := bytes.NewReader(b)
+	return o.section.Tenant
+}
 
-	r, err := http.NewRequestWithContext(reqContext, "POST", url, byteReader)
-	if err != nil {
-		return result, err
+// sinks return sinks of shedule section.
+func (o *Shedule) sinks() []namedSink {
+	if o.section == nil {
+		return nil
 	}
 
-	r.Header.Add("Content-Type", "application/json")
+	return o.section.sinks
+}
 
-	res, err := http.DefaultClient.Do(r)
-	if err != nil {
-		return result, err
+// matchers return silence matchers with global matchers of section.
+func (o *Shedule) matchers() MatcherList {
+	if o.section == nil || len(o.section.GlobalMatchers) == 0 {
+		return o.Silence.Matchers
 	}
 
-	h, _ := json.Marshal(data)
-	logger.Sugar().Infof("Called POST body %v result: %v \n", string(h), res)
+	result := make(MatcherList, 0, len(o.Silence.Matchers)+len(o.section.GlobalMatchers))
+	result = append(result, o.Silence.Matchers...)
 
-	body, err := io.ReadAll(res.Body)
+	return append(result, o.section.GlobalMatchers...)
+}
 
-	res.Body.Close()
+// render execute comment and createdBy templates for silence window from start to end.
+func (o *Shedule) render(silence *Silence, start, end time.Time) error {
+	data := TemplateData{
+		Cron:     o.Spec(),
+		Matchers: silence.Matchers,
+		Vars:     o.Vars,
+	}
 
-	if err != nil {
-		return result, err
+	if o.section != nil {
+		data.Section = o.section.GetSectionName()
+		data.File = o.section.GetFilePath()
 	}
 
-	if err := json.Unmarshal(body, &result); err != nil {
-		return result, err
+	data.Start = start.In(o.location())
+	data.End = end.In(o.location())
+	data.Zone, _ = data.Start.Zone()
+
+	var err error
+
+	if silence.Comment, err = renderTemplate("comment", silence.Comment, data); err != nil {
+		return err
 	}
 
-	stat.AddSheduleRun(fmt.Sprintf("%v;%v;%v;%s;%#v\n", time.Now().UTC(), data.StartsAt, data.EndsAt, data.Comment, data.Matchers))
+	silence.CreatedBy, err = renderTemplate("createdBy", silence.CreatedBy, data)
 
-	return result, nil
+	return err
 }
@@ -1,29 +1,40 @@
 package models
 
 import (
+	"context"
 	"fmt"
-
-	"github.com/Volkov-Stanislav/cron"
-	"github.com/Volkov-Stanislav/silences-sheduler/metrics"
-	"github.com/Volkov-Stanislav/silences-sheduler/stats"
-	"github.com/Volkov-Stanislav/silences-sheduler/utils"
-	"github.com/go-logr/zapr"
-	"go.uber.org/zap"
+	"path/filepath"
+	"time"
 )
 
 // SheduleSection set of Shedules from one config file and TimeOffset.
 type SheduleSection struct {
-	Shedules       []Shedule  `yaml:"shedules"`       // Shedules in section.
-	TimeOffset     string     `yaml:"timeoffset"`     // Offset in hours from UTC. May be + and -. ""=local
-	GlobalMatchers []Matchers `yaml:"globalmatchers"` // Matchers added in all silences in SeduleSection
-	cron           *cron.Cron
-	sectionName    string `` // Section name, for filestorage = filename
-	token          string `` // Token for identifiend datachange. (modified date for files for filestorage)
+	Shedules       []Shedule            `yaml:"shedules"`       // Shedules in section.
+	TimeOffset     string               `yaml:"timeoffset"`     // Offset in hours from UTC. May be + and -. ""=local
+	GlobalMatchers MatcherList          `yaml:"globalmatchers"` // Matchers added in all silences in SeduleSection
+	Tenant         string               `yaml:"tenant"`         // Alertmanager tenant (X-Scope-OrgID) for silences. ""=process default
+	Sink           string               `yaml:"sink"`           // Name of sink for silences (alertmanager, grafana). ""=alertmanager
+	PagerDuty      *PagerDutyTarget     `yaml:"pagerduty"`      // Optional PagerDuty maintenance windows for shedules.
+	Zabbix         *ZabbixTarget        `yaml:"zabbix"`         // Optional Zabbix maintenance periods for shedules.
+	Webhooks       []WebhookTarget      `yaml:"webhooks"`       // Optional webhooks for silence lifecycle events.
+	ValidFrom      string               `yaml:"validFrom"`      // Date or time since shedules of section are active. ""=unlimited
+	ValidUntil     string               `yaml:"validUntil"`     // Date (inclusive) or time until shedules of section are active. ""=unlimited
+	Calendars      map[string]*Calendar `yaml:"calendars"`      // Exclusion calendars by name, visible in all sections.
+	Exclude        []string             `yaml:"exclude"`        // Names of exclusion calendars for all shedules of section.
+	Enabled        *bool                `yaml:"enabled"`        // Shedules of section create silences. nil=true
+	MaxDuration    Duration             `yaml:"maxDuration"`    // Maximum duration of silences, lower than maxDuration of policy guardrails. 0=policy limit
+	scheduler      Scheduler            // Scheduler of shedules, set on run.
+	jobs           *sectionJobs         // Running jobs of shedules, set on run.
+	sinks          []namedSink          // Sinks of section, set on run.
+	sectionName    string               `` // Section name, for filestorage = filename
+	filePath       string               `` // Path of file with section, for filestorage.
+	token          string               `` // Token for identifiend datachange. (modified date for files for filestorage)
 }
 
 // String interface.
 func (o *SheduleSection) String() string {
-	return fmt.Sprintf("%#v", o)
+	return fmt.Sprintf("section %v (%v) tenant %q sinks %v global matchers %v shedules %v",
+		o.sectionName, o.filePath, o.Tenant, o.GetSinkNames(), o.GlobalMatchers, o.Shedules)
 }
 
 // GetSectionName return section name.
@@ -36,6 +47,58 @@ func (o *SheduleSection) SetSectionName(name string) {
 	o.sectionName = name
 }
 
+// GetFilePath return path of file with section.
+func (o *SheduleSection) GetFilePath() string {
+	return o.filePath
+}
+
+// SetFilePath set path of file with section.
+func (o *SheduleSection) SetFilePath(path string) {
+	o.filePath = path
+}
+
+// CheckTemplates check syntax of silence templates of all shedules in section.
+func (o *SheduleSection) CheckTemplates() error {
+	for key := range o.Shedules {
+		if err := o.Shedules[key].CheckTemplates(); err != nil {
+			return fmt.Errorf("shedule %v: %w", o.Shedules[key].Spec(), err)
+		}
+	}
+
+	return nil
+}
+
+// CheckWindows check window definitions of all shedules in section at now.
+func (o *SheduleSection) CheckWindows(now time.Time) error {
+	for key := range o.Shedules {
+		o.Shedules[key].section = o
+
+		if err := o.Shedules[key].CheckWindow(now); err != nil {
+			return fmt.Errorf("shedule %v: %w", o.Shedules[key].Spec(), err)
+		}
+	}
+
+	return nil
+}
+
+// LoadCalendars load exclusion calendars of section at now, relative paths resolved from directory of section file.
+func (o *SheduleSection) LoadCalendars(now time.Time) []error {
+	var result []error
+
+	for name, calendar := range o.Calendars {
+		if err := calendar.Load(filepath.Dir(o.filePath), now); err != nil {
+			result = append(result, fmt.Errorf("calendar %v: %w", name, err))
+		}
+	}
+
+	return result
+}
+
+// IsEnabled check if section is enabled in config.
+func (o *SheduleSection) IsEnabled() bool {
+	return o.Enabled == nil || *o.Enabled
+}
+
 // GetToken return token for section.
 func (o *SheduleSection) GetToken() string {
 	return o.token
@@ -46,55 +109,248 @@ func (o *SheduleSection) SetToken(token string) {
 	o.token = token
 }
 
+// GetSinkName return name of sink for silences of section.
+func (o *SheduleSection) GetSinkName() string {
+	if o.Sink == "" {
+		return DefaultSink
+	}
+
+	return o.Sink
+}
+
+// GetSinkNames return names of all sinks of section: sink for silences and optional targets.
+func (o *SheduleSection) GetSinkNames() []string {
+	result := []string{o.GetSinkName()}
+
+	if o.PagerDuty != nil {
+		result = append(result, PagerDutySink)
+	}
+
+	if o.Zabbix != nil {
+		result = append(result, ZabbixSink)
+	}
+
+	return result
+}
+
 // Run begin executing shedules from section.
-func (o *SheduleSection) Run(apiURL string, logger *zap.Logger, stat *stats.Instance, prom *metrics.Instance) {
-	if logger != nil {
-		log := zapr.NewLogger(logger)
-		o.cron = cron.New(cron.WithSeconds(), cron.WithLogger(log), cron.WithLocation(utils.GetLocation(o.TimeOffset)))
-	} else {
-		o.cron = cron.New(cron.WithSeconds(), cron.WithLocation(utils.GetLocation(o.TimeOffset)))
+func (o *SheduleSection) Run(env *Environment) {
+	logger := env.Logger
+
+	o.sinks = nil
+
+	for _, name := range o.GetSinkNames() {
+		sink, ok := env.Sinks[name]
+
+		switch {
+		case !ok && name == o.GetSinkName():
+			logger.Error(fmt.Sprintf("Unknown sink %q in section %v, shedules not started", name, o.sectionName))
+			return
+		case !ok:
+			// optional targets never disable sink of silences.
+			logger.Error(fmt.Sprintf("Target %q of section %v is not configured, skipped", name, o.sectionName))
+			continue
+		}
+
+		o.sinks = append(o.sinks, namedSink{name: name, sink: sink})
 	}
 
+	for _, err := range o.LoadCalendars(env.now()) {
+		logger.Error(fmt.Sprintf("Error load calendar in section %v: %v", o.sectionName, err))
+	}
+
+	if env.Scheduler == nil {
+		logger.Error(fmt.Sprintf("No scheduler for section %v, shedules not started", o.sectionName))
+		return
+	}
+
+	o.scheduler = env.Scheduler
+	o.jobs = &sectionJobs{}
+
 	for key := range o.Shedules {
+		o.Shedules[key].section = o
 		shed := o.Shedules[key]
-		entryID, err := o.cron.AddFunc(o.Shedules[key].Cron, func() {
-			shed.Run(apiURL, logger, stat, prom)
-		})
 
-		o.Shedules[key].SetEntryID(entryID)
+		if err := shed.CheckGuardrails(env, env.now()); err != nil {
+			logger.Error(fmt.Sprintf("Shedule %v in section %v refused: %v", shed.Spec(), o.sectionName, err))
+			continue
+		}
+
+		if shed.IsOneOff() {
+			o.runOnce(env, key)
+			continue
+		}
 
+		schedule, err := shed.Schedule()
 		if err != nil {
 			logger.Error(fmt.Sprintf("Error add Shedule: %v , err: %v", o.Shedules[key], err))
+			continue
+		}
+
+		o.Shedules[key].SetEntryID(o.scheduler.Add(schedule, shed.location(), func() {
+			o.jobs.run(func() { shed.Run(env) })
+		}))
+	}
+}
+
+// runOnce shedule one-off shedule with key: run it now if window in progress, or at start of window.
+func (o *SheduleSection) runOnce(env *Environment, key int) {
+	shed := o.Shedules[key]
+
+	start, end, err := shed.OneOffWindow()
+	if err != nil {
+		env.Logger.Error(fmt.Sprintf("Error add one-off Shedule: %v , err: %v", shed.Spec(), err))
+		return
+	}
+
+	now := env.now()
+
+	switch {
+	case !now.Before(end):
+		env.Logger.Info(fmt.Sprintf("One-off shedule %v in section %v expired at %v", shed.Spec(), o.sectionName, end))
+	case !now.Before(start):
+		go o.jobs.run(func() { shed.Run(env) })
+	default:
+		o.Shedules[key].SetEntryID(o.scheduler.Add(onceSchedule{start: start}, shed.location(), func() {
+			o.jobs.run(func() { shed.Run(env) })
+		}))
+	}
+}
+
+// RunNow run shedule with id immediately as job of section, so Shutdown waits for it. by is user requested run.
+// Return false, if section has no shedule with id or it is stopped.
+func (o *SheduleSection) RunNow(env *Environment, id, by string) bool {
+	if o.jobs == nil {
+		return false
+	}
+
+	for key := range o.Shedules {
+		if o.Shedules[key].ID() == id {
+			shed := o.Shedules[key]
+			return o.jobs.run(func() { shed.RunNow(env, by) })
 		}
 	}
 
-	o.cron.Start()
+	return false
 }
 
-// Stop executing shedules from section.
+// Stop executing shedules from section, running shedules are not interrupted.
 func (o *SheduleSection) Stop() {
 	fmt.Println("(o *SheduleSection) Stop()")
 
-	if o.cron != nil {
-		o.cron.Stop()
+	if o.jobs == nil {
+		return
+	}
+
+	o.jobs.stop()
+
+	for key := range o.Shedules {
+		if id := o.Shedules[key].GetEntryID(); id != 0 {
+			o.scheduler.Remove(id)
+		}
+	}
+}
+
+// Shutdown stop executing shedules from section and wait for running jobs until ctx is done.
+func (o *SheduleSection) Shutdown(ctx context.Context) error {
+	if o.jobs == nil {
+		return nil
+	}
+
+	o.Stop()
+
+	running := make(chan struct{})
+
+	go func() {
+		o.jobs.running.Wait()
+		close(running)
+	}()
+
+	select {
+	case <-running:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("section %v: %w", o.sectionName, ctx.Err())
 	}
 }
 
-// GetSectionForWeb return formatted text of runned section for web report.
-func (o *SheduleSection) GetSectionForWeb() []string {
+// GetSectionForWeb return formatted text of runned section for web report, pauses are taken from env.
+func (o *SheduleSection) GetSectionForWeb(env *Environment) []string {
 	var (
 		result []string
 		res    string
 	)
 
+	if o.jobs == nil {
+		return result
+	}
+
 	for shed := range o.Shedules {
-		res = fmt.Sprintf("%v;%v;%v;%v\n",
-			o.Shedules[shed].Cron,
+		res = fmt.Sprintf("%v;%v;%v;%v;%v\n",
+			o.Shedules[shed].ID(),
+			o.Shedules[shed].Spec(),
 			o.Shedules[shed].Silence.Comment,
 			o.Shedules[shed].Silence.Matchers,
-			o.cron.Entry(o.Shedules[shed].GetEntryID()).Next)
+			o.nextForWeb(env, &o.Shedules[shed]))
 		result = append(result, res)
 	}
 
 	return result
 }
+
+// nextForWeb return next run time of shedule, or state of shedule without next run.
+func (o *SheduleSection) nextForWeb(env *Environment, shed *Shedule) string {
+	now := env.now()
+	state, at, err := shed.Status(now)
+
+	switch state {
+	case StateInvalid:
+		return "invalid: " + err.Error()
+	case StateNotYetActive:
+		return fmt.Sprintf("not yet active, valid from %v", at)
+	case StateExpired:
+		return fmt.Sprintf("expired at %v", at)
+	}
+
+	if err := shed.CheckGuardrails(env, now); err != nil {
+		return "refused: " + err.Error()
+	}
+
+	if !shed.IsEnabled() {
+		return "disabled"
+	}
+
+	if pause, ok := o.pauseOf(env, shed, now); ok {
+		if pause.Until.IsZero() {
+			return fmt.Sprintf("paused by %v: %v", pause.By, pause.Reason)
+		}
+
+		return fmt.Sprintf("paused until %v by %v: %v", pause.Until, pause.By, pause.Reason)
+	}
+
+	next := o.scheduler.Next(shed.GetEntryID())
+	if shed.IsOneOff() && next.IsZero() {
+		return fmt.Sprintf("active until %v", at)
+	}
+
+	if env != nil && env.Skips != nil {
+		if skip, ok := env.Skips.Get(shed.ID()); ok {
+			return fmt.Sprintf("%v, skip next %v by %v", next, skip.Count, skip.By)
+		}
+	}
+
+	return fmt.Sprint(next)
+}
+
+// pauseOf return active pause of shedule or of section.
+func (o *SheduleSection) pauseOf(env *Environment, shed *Shedule, now time.Time) (Pause, bool) {
+	if env == nil || env.Pauses == nil {
+		return Pause{}, false
+	}
+
+	if pause, ok := env.Pauses.Get(PauseShedule, shed.ID(), now); ok {
+		return pause, true
+	}
+
+	return env.Pauses.Get(PauseSection, o.sectionName, now)
+}
@@ -7,16 +7,17 @@ import (
 
 // Silence type of Alertmanager silence.
 type Silence struct {
-	Comment   string     `json:"comment" yaml:"comment"`
-	CreatedBy string     `json:"createdBy" yaml:"createdBy"`
-	EndsAt    time.Time  `json:"endsAt" yaml:"endsAt"`
-	StartsAt  time.Time  `json:"startsAt" yaml:"startAt"`
-	Matchers  []Matchers `json:"matchers" yaml:"matchers"`
+	ID        string      `json:"id,omitempty" yaml:"-"`
+	Comment   string      `json:"comment" yaml:"comment"`
+	CreatedBy string      `json:"createdBy" yaml:"createdBy"`
+	EndsAt    time.Time   `json:"endsAt" yaml:"endsAt"`
+	StartsAt  time.Time   `json:"startsAt" yaml:"startAt"`
+	Matchers  MatcherList `json:"matchers" yaml:"matchers"`
 }
 
 // String stringer interface.
 func (o Silence) String() string {
-	return fmt.Sprintf("%#v", o)
+	return fmt.Sprintf("%v %v - %v comment %q createdBy %q", o.Matchers, o.StartsAt, o.EndsAt, o.Comment, o.CreatedBy)
 }
 
 // SilenceID type for parsing reply from Alertmanager.
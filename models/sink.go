@@ -0,0 +1,172 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Volkov-Stanislav/cron"
+	"github.com/Volkov-Stanislav/silences-sheduler/clock"
+	"github.com/Volkov-Stanislav/silences-sheduler/metrics"
+	"github.com/Volkov-Stanislav/silences-sheduler/stats"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultSink name of sink used by sections without "sink" field.
+	DefaultSink = "alertmanager"
+	// PagerDutySink name of PagerDuty maintenance windows target.
+	PagerDutySink = "pagerduty"
+	// ZabbixSink name of Zabbix maintenance periods target.
+	ZabbixSink = "zabbix"
+)
+
+// Window one silence window of running shedule, passed to sinks.
+type Window struct {
+	Section *SheduleSection // Section of shedule.
+	Shedule *Shedule        // Shedule fired window.
+	Silence Silence         // Silence with rendered templates and all matchers.
+	Tenant  string          // Tenant of section.
+	Start   time.Time       // Start of window.
+	End     time.Time       // End of window.
+	By      string          // User requested manual run, empty for sheduled run.
+}
+
+// Sink is target for creating and expiring silences.
+type Sink interface {
+	// Create silence for window, return ID of created silence.
+	Create(ctx context.Context, w Window) (string, error)
+	// Expire silence with ID, created by Create.
+	Expire(ctx context.Context, w Window, id string) error
+}
+
+// namedSink sink with name from Environment.
+type namedSink struct {
+	name string
+	sink Sink
+}
+
+// Environment set of shared services for running shedules.
+type Environment struct {
+	Sinks     map[string]Sink // Sinks by name, section select sink by "sink" field.
+	Logger    *zap.Logger
+	Stat      *stats.Instance
+	Prom      *metrics.Instance
+	Notifier  Notifier        // Notifier of silence lifecycle events, may be nil.
+	Active    *ActiveSilences // Silences created by scheduler, may be nil.
+	Calendars *CalendarSet    // Exclusion calendars of all sections, may be nil.
+	Pauses    *Pauses         // Runtime pauses of sections and shedules, may be nil.
+	Skips     *Skips          // Requested skips of next runs of shedules, may be nil.
+	Freeze    *Freeze         // Global change-freeze switch, may be nil.
+	Policy    *Policy         // Mandatory and forbidden matchers of all silences, may be nil.
+	Scheduler Scheduler       // Scheduler of shedules of all sections, required for run of sections.
+	Clock     clock.Clock     // Source of current time, may be nil for real time.
+	// Context of requests to sinks, cancelled on shutdown after grace period, may be nil.
+	Context context.Context
+}
+
+// now return current time of clock of environment, env may be nil.
+func (o *Environment) now() time.Time {
+	if o == nil || o.Clock == nil {
+		return time.Now()
+	}
+
+	return o.Clock.Now()
+}
+
+// context return base context of requests to sinks.
+func (o *Environment) context() context.Context {
+	if o.Context == nil {
+		return context.Background()
+	}
+
+	return o.Context
+}
+
+// Expire silence created by scheduler and send expired event.
+func (o *Environment) Expire(ctx context.Context, active ActiveSilence) error {
+	sink, ok := o.Sinks[active.Sink]
+	if !ok {
+		return fmt.Errorf("unknown sink %q", active.Sink)
+	}
+
+	err := sink.Expire(ctx, active.Window, active.ID)
+	if err != nil {
+		o.notify(EventFailed, active.Sink, active.ID, active.Window, err)
+		return err
+	}
+
+	if o.Active != nil {
+		if err := o.Active.Remove(active); err != nil {
+			o.Logger.Sugar().Errorf("Error save active silences: %v", err)
+		}
+	}
+
+	o.notify(EventExpired, active.Sink, active.ID, active.Window, nil)
+
+	return nil
+}
+
+// setActive register active silence and watch its end.
+func (o *Environment) setActive(active ActiveSilence) {
+	if o.Active != nil {
+		if err := o.Active.Set(active); err != nil {
+			o.Logger.Sugar().Errorf("Error save active silences: %v", err)
+		}
+	}
+
+	o.watchEnd(active)
+}
+
+// watchEnd send expired event, when end of active silence passes, if it was not expired or extended before.
+func (o *Environment) watchEnd(active ActiveSilence) {
+	if o.Scheduler == nil {
+		return
+	}
+
+	id := make(chan cron.EntryID, 1)
+	id <- o.Scheduler.Add(onceSchedule{start: active.Window.End}, time.UTC, func() {
+		o.Scheduler.Remove(<-id)
+
+		if o.Active != nil {
+			ended, err := o.Active.Ended(active, o.now())
+			if err != nil {
+				o.Logger.Sugar().Errorf("Error save active silences: %v", err)
+			}
+
+			if !ended {
+				return
+			}
+		}
+
+		o.Logger.Sugar().Infof("Silence %v in %v expired at %v", active.ID, active.Sink, active.Window.End)
+		o.notify(EventExpired, active.Sink, active.ID, active.Window, nil)
+	})
+}
+
+// notify send silence lifecycle event for window to webhooks of its section.
+func (o *Environment) notify(eventType EventType, sink, id string, w Window, err error) {
+	if o.Notifier == nil || w.Section == nil || len(w.Section.Webhooks) == 0 {
+		return
+	}
+
+	event := Event{
+		Type:    eventType,
+		Time:    o.now().UTC(),
+		Section: w.Section.GetSectionName(),
+		File:    w.Section.GetFilePath(),
+		Sink:    sink,
+		Tenant:  w.Tenant,
+		ID:      id,
+		Start:   w.Start.UTC(),
+		End:     w.End.UTC(),
+		Silence: w.Silence,
+		Targets: w.Section.Webhooks,
+	}
+
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	o.Notifier.Notify(event)
+}
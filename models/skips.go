@@ -0,0 +1,62 @@
+package models
+
+import "sync"
+
+// Skip request to skip next runs of shedule.
+type Skip struct {
+	Count int    `json:"count"` // Count of next runs to skip.
+	By    string `json:"by"`    // User requested skip.
+}
+
+// Skips registry of requested skips of next runs by shedule ID.
+type Skips struct {
+	mux   sync.Mutex
+	items map[string]Skip
+}
+
+// NewSkips return empty registry of skips.
+func NewSkips() *Skips {
+	return &Skips{items: make(map[string]Skip)}
+}
+
+// Set request skip of next count runs of shedule id, count 0 cancel skip.
+func (o *Skips) Set(id string, skip Skip) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	if skip.Count <= 0 {
+		delete(o.items, id)
+		return
+	}
+
+	o.items[id] = skip
+}
+
+// Get return requested skip of shedule id.
+func (o *Skips) Get(id string) (Skip, bool) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	skip, ok := o.items[id]
+
+	return skip, ok
+}
+
+// Take decrease count of skips of shedule id, return skip before decrease and true if run must be skipped.
+func (o *Skips) Take(id string) (Skip, bool) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	skip, ok := o.items[id]
+	if !ok {
+		return skip, false
+	}
+
+	if skip.Count <= 1 {
+		delete(o.items, id)
+	} else {
+		o.items[id] = Skip{Count: skip.Count - 1, By: skip.By}
+	}
+
+	return skip, true
+}
@@ -0,0 +1,35 @@
+package models
+
+// PagerDutyTarget define PagerDuty maintenance windows, created in addition to silences.
+type PagerDutyTarget struct {
+	Services []string `yaml:"services"` // PagerDuty service IDs.
+}
+
+// ZabbixTarget define Zabbix maintenance periods, created in addition to silences.
+type ZabbixTarget struct {
+	Groups []string `yaml:"groups"` // Names of Zabbix host groups.
+	Hosts  []string `yaml:"hosts"`  // Technical names of Zabbix hosts.
+}
+
+// WebhookTarget define webhook receiving silence lifecycle events.
+type WebhookTarget struct {
+	URL        string      `yaml:"url"`        // URL for POST of JSON event.
+	Secret     string      `yaml:"secret"`     // Secret for HMAC-SHA256 signature of event.
+	SecretFile string      `yaml:"secretFile"` // File with secret, used if secret empty.
+	Events     []EventType `yaml:"events"`     // Types of sent events, empty for all events.
+}
+
+// Accept check if event type sent to webhook.
+func (o WebhookTarget) Accept(eventType EventType) bool {
+	if len(o.Events) == 0 {
+		return true
+	}
+
+	for _, accepted := range o.Events {
+		if accepted == eventType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateData data available in comment and createdBy templates of silence.
+type TemplateData struct {
+	Section  string            // Section name, for filestorage = filename.
+	File     string            // Path of file with section.
+	Cron     string            // Crontab of shedule.
+	Start    time.Time         // Start of silence window in section time zone.
+	End      time.Time         // End of silence window in section time zone.
+	Zone     string            // Name of section time zone.
+	Matchers MatcherList       // Matchers of silence, with global matchers of section.
+	Vars     map[string]string // Custom variables of shedule.
+}
+
+var templateFuncs = template.FuncMap{
+	"base":  filepath.Base,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// renderTemplate execute text as Go template with data.
+// Text without template actions returned as is.
+func renderTemplate(name, text string, data TemplateData) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return text, err
+	}
+
+	var buf bytes.Buffer
+
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return text, err
+	}
+
+	return buf.String(), nil
+}
+
+// checkTemplate parse text as Go template, without executing.
+func checkTemplate(name, text string) error {
+	_, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	return err
+}
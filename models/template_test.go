@@ -0,0 +1,101 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+func TestShedule_RenderTemplates(t *testing.T) {
+	tests := []struct {
+		name          string
+		comment       string
+		createdBy     string
+		wantComment   string
+		wantCreatedBy string
+	}{
+		{
+			name:          "Plain text",
+			comment:       "Backup window",
+			createdBy:     "bot",
+			wantComment:   "Backup window",
+			wantCreatedBy: "bot",
+		},
+		{
+			name:          "Window in section time zone",
+			comment:       `{{.Section}} {{.Start.Format "15:04"}}-{{.End.Format "15:04"}} {{.Zone}}`,
+			createdBy:     "{{upper .Vars.team}}",
+			wantComment:   "night.yaml 01:00-03:00 UTC3",
+			wantCreatedBy: "DBA",
+		},
+		{
+			name:          "Cron, file and matchers",
+			comment:       "{{.Cron}} {{base .File}} {{.Matchers}}",
+			createdBy:     "{{lower .Vars.missing}}",
+			wantComment:   `0 0 1 * * * night.yaml {service="db", env="prod"}`,
+			wantCreatedBy: "",
+		},
+		{
+			name:          "Execution error keeps template text",
+			comment:       "{{.Start.Unknown}}",
+			createdBy:     "bot",
+			wantComment:   "{{.Start.Unknown}}",
+			wantCreatedBy: "bot",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section := models.SheduleSection{
+				TimeOffset:     "+3",
+				GlobalMatchers: models.MatcherList{{IsEqual: true, Name: "env", Value: "prod"}},
+				Shedules: []models.Shedule{{
+					Cron:     "0 0 1 * * *",
+					Duration: models.Duration(2 * time.Hour),
+					Vars:     map[string]string{"team": "dba"},
+					Silence: models.Silence{
+						Matchers:  models.MatcherList{{IsEqual: true, Name: "service", Value: "db"}},
+						Comment:   tt.comment,
+						CreatedBy: tt.createdBy,
+					},
+				}},
+			}
+			section.SetSectionName("night.yaml")
+			section.SetFilePath("/etc/shedules/night.yaml")
+
+			from := time.Date(2026, 11, 3, 0, 0, 0, 0, time.FixedZone("", 3*60*60))
+
+			windows := section.Upcoming(nil, from, from.AddDate(0, 0, 1), 1)
+			if len(windows) != 1 {
+				t.Fatalf("Upcoming() = %v windows, want 1", len(windows))
+			}
+
+			silence := windows[0].Silence
+			if silence.Comment != tt.wantComment || silence.CreatedBy != tt.wantCreatedBy {
+				t.Errorf("rendered %q, %q, want %q, %q", silence.Comment, silence.CreatedBy, tt.wantComment, tt.wantCreatedBy)
+			}
+		})
+	}
+}
+
+func TestShedule_CheckTemplates(t *testing.T) {
+	tests := []struct {
+		name    string
+		silence models.Silence
+		wantErr bool
+	}{
+		{name: "Valid", silence: models.Silence{Comment: "{{.Section}}", CreatedBy: "{{upper .Vars.team}}"}},
+		{name: "Unclosed action in comment", silence: models.Silence{Comment: "{{.Section"}, wantErr: true},
+		{name: "Unknown function in createdBy", silence: models.Silence{CreatedBy: "{{title .Section}}"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shed := models.Shedule{Cron: "0 0 1 * * *", Silence: tt.silence}
+			if err := shed.CheckTemplates(); (err != nil) != tt.wantErr {
+				t.Errorf("CheckTemplates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// maxUpcoming limit of upcoming windows of one shedule, for crons with short period.
+	maxUpcoming = 1000
+	// maxUpcomingSteps limit of checked activations of one shedule, including skipped by calendars.
+	maxUpcomingSteps = 100 * maxUpcoming
+)
+
+// Upcoming return silence windows of shedule, which start before to and end after from, in shedule time zone.
+// Windows skipped by validity ranges, exclusion calendars and pauses are omitted. At most limit windows returned, 0=maxUpcoming.
+func (o *Shedule) Upcoming(env *Environment, from, to time.Time, limit int) []Window {
+	var result []Window
+
+	if limit <= 0 || limit > maxUpcoming {
+		limit = maxUpcoming
+	}
+
+	if !o.IsEnabled() {
+		return nil
+	}
+
+	if o.IsOneOff() {
+		start, end, err := o.OneOffWindow()
+		if err != nil || !start.Before(to) || !end.After(from) {
+			return nil
+		}
+
+		if state, _, _ := o.Status(start); state == StateInvalid || state == StateNotYetActive {
+			return nil
+		}
+
+		if _, ok := o.paused(env, start); ok {
+			return nil
+		}
+
+		return []Window{o.window(env, start, end)}
+	}
+
+	schedule, err := o.Schedule()
+	if err != nil {
+		return nil
+	}
+
+	// window started before from may still be in progress.
+	at := from.Add(-time.Duration(o.Duration))
+	if o.Until != "" {
+		at = from.AddDate(0, 0, -1)
+	}
+
+	for i := 0; len(result) < limit && i < maxUpcomingSteps; i++ {
+		start := schedule.Next(at.In(o.location()))
+		if start.IsZero() || !start.Before(to) {
+			break
+		}
+
+		at = start
+
+		end := o.WindowEnd(start)
+		if !end.After(from) {
+			continue
+		}
+
+		state, change, _ := o.Status(start)
+		if state == StateExpired || state == StateInvalid {
+			break
+		}
+
+		if state == StateNotYetActive {
+			at = change.Add(-time.Nanosecond)
+			continue
+		}
+
+		if _, ok := o.paused(env, start); ok {
+			continue
+		}
+
+		if _, ok, _ := o.excluding(env, start); ok {
+			continue
+		}
+
+		result = append(result, o.window(env, start, end))
+	}
+
+	return result
+}
+
+// window return silence window from start to end with rendered templates and matchers of policy.
+func (o *Shedule) window(env *Environment, start, end time.Time) Window {
+	silence := o.Silence
+	silence.Matchers = o.matchers()
+
+	if env != nil {
+		_ = env.Policy.Apply(&silence)
+	}
+	silence.StartsAt = start.UTC()
+	silence.EndsAt = end.UTC()
+	_ = o.render(&silence, start, end)
+
+	return Window{
+		Section: o.section,
+		Shedule: o,
+		Silence: silence,
+		Tenant:  o.tenant(),
+		Start:   start.In(o.location()),
+		End:     end.In(o.location()),
+	}
+}
+
+// Upcoming return silence windows of all shedules of section from from to to, sorted by start.
+func (o *SheduleSection) Upcoming(env *Environment, from, to time.Time, limit int) []Window {
+	var result []Window
+
+	// copies of shedules, section may be read concurrently.
+	for _, shed := range o.Shedules {
+		shed.section = o
+		result = append(result, shed.Upcoming(env, from, to, limit)...)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Start.Before(result[j].Start)
+	})
+
+	return result
+}
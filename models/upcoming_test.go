@@ -0,0 +1,62 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+func TestSheduleSection_Upcoming(t *testing.T) {
+	section := models.SheduleSection{
+		TimeOffset: "+3",
+		Calendars: map[string]*models.Calendar{
+			"holidays": {Dates: []string{"2026-11-04"}},
+		},
+		Shedules: []models.Shedule{
+			{Cron: "0 0 22 * * *", Until: "06:00", Exclude: []string{"holidays"}, ValidUntil: "2026-11-06"},
+			{Start: "2026-11-03T10:00", End: "2026-11-03T14:00", Silence: models.Silence{Comment: "{{.Section}}"}},
+		},
+	}
+	section.SetSectionName("night.yaml")
+
+	if err := section.Calendars["holidays"].Load("", time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+
+	loc := time.FixedZone("", 3*60*60)
+	from := time.Date(2026, 11, 3, 3, 0, 0, 0, loc)
+	to := from.AddDate(0, 0, 7)
+
+	windows := section.Upcoming(nil, from, to, 0)
+
+	want := []time.Time{
+		time.Date(2026, 11, 2, 22, 0, 0, 0, loc), // in progress at from.
+		time.Date(2026, 11, 3, 10, 0, 0, 0, loc),
+		time.Date(2026, 11, 3, 22, 0, 0, 0, loc),
+		time.Date(2026, 11, 5, 22, 0, 0, 0, loc),
+		time.Date(2026, 11, 6, 22, 0, 0, 0, loc),
+	}
+
+	if len(windows) != len(want) {
+		t.Fatalf("Upcoming() = %v windows, want %v", len(windows), len(want))
+	}
+
+	for i := range want {
+		if !windows[i].Start.Equal(want[i]) {
+			t.Errorf("Upcoming()[%v].Start = %v, want %v", i, windows[i].Start, want[i])
+		}
+	}
+
+	if end := windows[0].End; !end.Equal(time.Date(2026, 11, 3, 6, 0, 0, 0, loc)) {
+		t.Errorf("Upcoming()[0].End = %v", end)
+	}
+
+	if comment := windows[1].Silence.Comment; comment != "night.yaml" {
+		t.Errorf("Upcoming()[1].Silence.Comment = %q, want rendered template", comment)
+	}
+
+	if limited := section.Upcoming(nil, from, to, 1); len(limited) != 2 {
+		t.Errorf("Upcoming() with limit 1 = %v windows, want 1 per shedule", len(limited))
+	}
+}
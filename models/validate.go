@@ -0,0 +1,51 @@
+package models
+
+import "fmt"
+
+// Validate check section: silence templates, windows, schedules of shedules, exclusion calendars, policy and guardrails.
+// Calendars are searched in section and in env, env may be nil.
+func (o *SheduleSection) Validate(env *Environment) []error {
+	var result []error
+
+	for key := range o.Shedules {
+		shed := &o.Shedules[key]
+		shed.section = o
+
+		if err := shed.CheckTemplates(); err != nil {
+			result = append(result, fmt.Errorf("shedule %v: templates: %w", shed.Spec(), err))
+		}
+
+		if err := shed.CheckWindow(env.now()); err != nil {
+			result = append(result, fmt.Errorf("shedule %v: window: %w", shed.Spec(), err))
+		}
+
+		if _, _, err := shed.validRange(); err != nil {
+			result = append(result, fmt.Errorf("shedule %v: validity: %w", shed.Spec(), err))
+		}
+
+		if !shed.IsOneOff() {
+			if _, err := shed.Schedule(); err != nil {
+				result = append(result, fmt.Errorf("shedule %v: schedule: %w", shed.Spec(), err))
+			}
+		}
+
+		if env != nil {
+			silence := Silence{Matchers: shed.matchers()}
+			if err := env.Policy.Apply(&silence); err != nil {
+				result = append(result, fmt.Errorf("shedule %v: %w", shed.Spec(), err))
+			}
+		}
+
+		if err := shed.CheckGuardrails(env, env.now()); err != nil {
+			result = append(result, fmt.Errorf("shedule %v: %w", shed.Spec(), err))
+		}
+
+		for _, name := range append(append([]string{}, o.Exclude...), shed.Exclude...) {
+			if _, ok := shed.calendar(env, name); !ok {
+				result = append(result, fmt.Errorf("shedule %v: unknown calendar %v", shed.Spec(), name))
+			}
+		}
+	}
+
+	return result
+}
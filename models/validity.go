@@ -0,0 +1,103 @@
+package models
+
+import (
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/utils"
+)
+
+// State state of shedule at some time.
+type State string
+
+// States of shedule.
+const (
+	StateActive       State = "active"
+	StateNotYetActive State = "not yet active"
+	StateExpired      State = "expired"
+	StateInvalid      State = "invalid"
+)
+
+// Status return state of shedule at now, with time of its change: start of validity for not yet active shedule,
+// end of validity or one-off window for active and expired shedule (zero if unlimited).
+func (o *Shedule) Status(now time.Time) (State, time.Time, error) {
+	from, until, err := o.validRange()
+	if err != nil {
+		return StateInvalid, time.Time{}, err
+	}
+
+	if o.IsOneOff() {
+		_, end, err := o.OneOffWindow()
+		if err != nil {
+			return StateInvalid, time.Time{}, err
+		}
+
+		if until.IsZero() || end.Before(until) {
+			until = end
+		}
+	}
+
+	switch {
+	case !from.IsZero() && now.Before(from):
+		return StateNotYetActive, from, nil
+	case !until.IsZero() && !now.Before(until):
+		return StateExpired, until, nil
+	default:
+		return StateActive, until, nil
+	}
+}
+
+// validRange return validity range of shedule, intersected with validity range of section.
+// Zero from or until mean unlimited range.
+func (o *Shedule) validRange() (from, until time.Time, err error) {
+	loc := o.location()
+
+	if from, err = parseValid(o.ValidFrom, loc, false); err != nil {
+		return from, until, err
+	}
+
+	if until, err = parseValid(o.ValidUntil, loc, true); err != nil {
+		return from, until, err
+	}
+
+	if o.section == nil {
+		return from, until, nil
+	}
+
+	sectFrom, err := parseValid(o.section.ValidFrom, loc, false)
+	if err != nil {
+		return from, until, err
+	}
+
+	sectUntil, err := parseValid(o.section.ValidUntil, loc, true)
+	if err != nil {
+		return from, until, err
+	}
+
+	if from.IsZero() || sectFrom.After(from) {
+		from = sectFrom
+	}
+
+	if until.IsZero() || (!sectUntil.IsZero() && sectUntil.Before(until)) {
+		until = sectUntil
+	}
+
+	return from, until, nil
+}
+
+// parseValid parse bound of validity range. Date without time in end of range include whole day.
+func parseValid(value string, loc *time.Location, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, layout, err := utils.ParseTimeLayout(value, loc)
+	if err != nil {
+		return t, err
+	}
+
+	if end && layout == utils.DateLayout {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,93 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+func TestShedule_Status(t *testing.T) {
+	now := time.Date(2026, 11, 3, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		shed    models.Shedule
+		want    models.State
+		wantAt  time.Time
+		wantErr bool
+	}{
+		{
+			name: "Unlimited",
+			shed: models.Shedule{Cron: "0 50 1 * * *"},
+			want: models.StateActive,
+		},
+		{
+			name:   "Not yet active",
+			shed:   models.Shedule{Cron: "0 50 1 * * *", ValidFrom: "2026-11-10"},
+			want:   models.StateNotYetActive,
+			wantAt: time.Date(2026, 11, 10, 0, 0, 0, 0, time.Local),
+		},
+		{
+			name:   "Valid until date include whole day",
+			shed:   models.Shedule{Cron: "0 50 1 * * *", ValidFrom: "2026-11-01", ValidUntil: "2026-11-03"},
+			want:   models.StateActive,
+			wantAt: time.Date(2026, 11, 4, 0, 0, 0, 0, time.Local),
+		},
+		{
+			name:   "Valid until date in zone include whole day",
+			shed:   models.Shedule{Cron: "0 50 1 * * *", ValidUntil: "2026-11-03 UTC"},
+			want:   models.StateActive,
+			wantAt: time.Date(2026, 11, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "Valid until date with spaces include whole day",
+			shed:   models.Shedule{Cron: "0 50 1 * * *", ValidUntil: " 2026-11-03 "},
+			want:   models.StateActive,
+			wantAt: time.Date(2026, 11, 4, 0, 0, 0, 0, time.Local),
+		},
+		{
+			name:   "Valid until midnight",
+			shed:   models.Shedule{Cron: "0 50 1 * * *", ValidUntil: "2026-11-04T00:00"},
+			want:   models.StateActive,
+			wantAt: time.Date(2026, 11, 4, 0, 0, 0, 0, time.Local),
+		},
+		{
+			name:   "Expired",
+			shed:   models.Shedule{Cron: "0 50 1 * * *", ValidUntil: "2026-11-03T11:00"},
+			want:   models.StateExpired,
+			wantAt: time.Date(2026, 11, 3, 11, 0, 0, 0, time.Local),
+		},
+		{
+			name:   "One-off in progress",
+			shed:   models.Shedule{Start: "2026-11-03T10:00", End: "2026-11-03T14:00"},
+			want:   models.StateActive,
+			wantAt: time.Date(2026, 11, 3, 14, 0, 0, 0, time.Local),
+		},
+		{
+			name:   "One-off expired",
+			shed:   models.Shedule{Start: "2026-11-02T22:00", End: "2026-11-03T04:00"},
+			want:   models.StateExpired,
+			wantAt: time.Date(2026, 11, 3, 4, 0, 0, 0, time.Local),
+		},
+		{
+			name:    "Invalid date",
+			shed:    models.Shedule{Cron: "0 50 1 * * *", ValidFrom: "next week"},
+			want:    models.StateInvalid,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, at, err := tt.shed.Status(now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Status() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want || !at.Equal(tt.wantAt) {
+				t.Errorf("Status() = %v, %v, want %v, %v", got, at, tt.want, tt.wantAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+// ErrSheduleNotFound returned by actions with ID of not runned shedule.
+var ErrSheduleNotFound = errors.New("shedule not found")
+
+// SkipNext skip next count runs of shedule with id, count 0 cancel skip. by is user requested skip.
+func (o *Runner) SkipNext(id string, count int, by string) error {
+	if _, ok := o.findSection(id); !ok {
+		return fmt.Errorf("%w: %v", ErrSheduleNotFound, id)
+	}
+
+	o.env.Skips.Set(id, models.Skip{Count: count, By: by})
+	o.logger.Sugar().Infof("Skip next %v runs of shedule %v requested by %v", count, id, by)
+
+	return nil
+}
+
+// RunNow create silence of shedule with id immediately, for its configured duration. by is user requested run.
+// Run is job of section of shedule, Shutdown waits for it.
+func (o *Runner) RunNow(id, by string) error {
+	section, ok := o.findSection(id)
+
+	// section may be stopped by reload or shutdown after lookup.
+	if !ok || !section.RunNow(&o.env, id, by) {
+		return fmt.Errorf("%w: %v", ErrSheduleNotFound, id)
+	}
+
+	return nil
+}
+
+// findSection return runned section of shedule with id.
+func (o *Runner) findSection(id string) (*models.SheduleSection, bool) {
+	o.mux.RLock()
+	defer o.mux.RUnlock()
+
+	for _, section := range o.sheds {
+		for key := range section.Shedules {
+			if section.Shedules[key].ID() == id {
+				return section, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// SkipHandler return handler of skip of next runs, authorized by control API auth:
+// POST /skip?id=<shedule ID>&count=<N>, count default 1, 0 cancel skip.
+func (o *Runner) SkipHandler() http.Handler {
+	return o.control(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		count := 1
+
+		if value := r.URL.Query().Get("count"); value != "" {
+			var err error
+
+			if count, err = strconv.Atoi(value); err != nil || count < 0 {
+				http.Error(w, fmt.Sprintf("invalid count %q", value), http.StatusBadRequest)
+				return
+			}
+		}
+
+		skip := models.Skip{Count: count, By: o.auth.user(r)}
+
+		if err := o.SkipNext(r.URL.Query().Get("id"), skip.Count, skip.By); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		o.writeJSON(w, skip)
+	}))
+}
+
+// RunNowHandler return handler of immediate run of shedule, authorized by control API auth: POST /run?id=<shedule ID>.
+func (o *Runner) RunNowHandler() http.Handler {
+	return o.control(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err := o.RunNow(r.URL.Query().Get("id"), o.auth.user(r)); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+}
@@ -0,0 +1,179 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/clock"
+	"github.com/Volkov-Stanislav/silences-sheduler/metrics"
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/service"
+	"github.com/Volkov-Stanislav/silences-sheduler/stats"
+	"go.uber.org/zap"
+)
+
+// prom metrics shared by tests, metrics may be registered only once.
+var prom = metrics.NewPrometheusInstance("0")
+
+// fakeSink records created windows and expired silences.
+type fakeSink struct {
+	mux     sync.Mutex
+	windows []models.Window
+	expired []string
+}
+
+func (o *fakeSink) Create(ctx context.Context, w models.Window) (string, error) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	o.windows = append(o.windows, w)
+
+	return "id", nil
+}
+
+func (o *fakeSink) Expire(ctx context.Context, w models.Window, id string) error {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	o.expired = append(o.expired, id)
+
+	return nil
+}
+
+func (o *fakeSink) created() []models.Window {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	return append([]models.Window{}, o.windows...)
+}
+
+func TestRunner_SkipAndRunNow(t *testing.T) {
+	logger := zap.NewNop()
+	sink := &fakeSink{}
+
+	runner, err := service.NewRunner(map[string]models.Sink{models.DefaultSink: sink}, nil, "", logger,
+		stats.NewInstance("0", logger), prom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner.SetControlAuth(service.ControlAuth{TrustedProxy: true})
+	runner.Start()
+	defer runner.Stop()
+
+	section := models.SheduleSection{Shedules: []models.Shedule{{
+		Cron:     "0 0 3 1 1 *",
+		Duration: models.Duration(time.Hour),
+		Silence:  models.Silence{Matchers: []models.Matchers{{IsEqual: true, Name: "service", Value: "db"}}},
+	}}}
+	section.SetSectionName("night.yaml")
+	section.SetToken("night")
+
+	add, _ := runner.GetChannels()
+	add <- section
+
+	occurrences := runner.Preview("night.yaml", time.Now(), time.Now().AddDate(1, 0, 1), 1)
+	if len(occurrences) != 1 {
+		t.Fatalf("Preview() = %v", occurrences)
+	}
+
+	id := occurrences[0].ID
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/run?id="+id, nil)
+	req.Header.Set("X-Forwarded-User", "ops")
+	runner.RunNowHandler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("run status = %v, body %v", rec.Code, rec.Body)
+	}
+
+	created := sink.created()
+	if len(created) != 1 || created[0].By != "ops" || created[0].End.Sub(created[0].Start) != time.Hour {
+		t.Fatalf("created windows = %v", created)
+	}
+
+	// request without X-Forwarded-User of auth proxy is rejected.
+	for _, handler := range []http.Handler{runner.RunNowHandler(), runner.SkipHandler()} {
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?id="+id+"&user=ops", nil))
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("status of request without user = %v, want %v", rec.Code, http.StatusUnauthorized)
+		}
+	}
+
+	if created := sink.created(); len(created) != 1 {
+		t.Errorf("created windows by unauthorized request = %v", created)
+	}
+
+	if err := runner.SkipNext(id, 1, "ops"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runner.SkipNext("unknown", 1, "ops"); err == nil {
+		t.Errorf("SkipNext() of unknown shedule error = nil")
+	}
+}
+
+func TestRunner_SkipAfterCalendarAndFreeze(t *testing.T) {
+	logger := zap.NewNop()
+	sink := &fakeSink{}
+	sentinel := filepath.Join(t.TempDir(), models.FreezeFileName)
+	fake := clock.NewFake(time.Date(2026, 11, 2, 0, 30, 0, 0, time.UTC))
+
+	runner, err := service.NewRunner(map[string]models.Sink{models.DefaultSink: sink}, nil, "", logger,
+		stats.NewInstance("0", logger), prom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner.SetClock(fake)
+	runner.SetFreeze(models.NewFreeze(false, sentinel), false)
+	runner.Start()
+	t.Cleanup(runner.Stop)
+
+	section := virtualSection("night", "UTC", models.Shedule{Cron: "0 0 1 * * *", Duration: models.Duration(time.Hour)})
+	section.Calendars = map[string]*models.Calendar{"holidays": {Ranges: []models.DateRange{{From: "2026-11-02", To: "2026-11-02"}}}}
+	section.Exclude = []string{"holidays"}
+	load(runner, nil, section)
+
+	id := runner.Preview("night.yaml", fake.Now(), fake.Now().AddDate(0, 0, 7), 1)[0].ID
+	if err := runner.SkipNext(id, 1, "ops"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 11-02 excluded by calendar, 11-03 frozen, 11-04 skipped, 11-05 created.
+	fake.Advance(30 * time.Minute)
+	time.Sleep(50 * time.Millisecond)
+
+	if err := os.WriteFile(sentinel, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.Advance(24 * time.Hour)
+	time.Sleep(50 * time.Millisecond)
+
+	if err := os.Remove(sentinel); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.Advance(24 * time.Hour)
+	time.Sleep(50 * time.Millisecond)
+
+	if created := sink.created(); len(created) != 0 {
+		t.Fatalf("created windows = %v, want skipped run on 2026-11-04", created)
+	}
+
+	fake.Advance(24 * time.Hour)
+
+	if window := waitCreated(t, sink, 1)[0]; window.Start.Day() != 5 {
+		t.Errorf("silence created at %v, want 2026-11-05", window.Start)
+	}
+}
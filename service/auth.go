@@ -0,0 +1,98 @@
+package service
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// ControlAuth authorization of mutating requests of control API: pause, resume, skip, run and freeze.
+// Without token and trusted proxy control API is read-only.
+type ControlAuth struct {
+	Token        string // Bearer token of mutating requests, empty if not required.
+	TrustedProxy bool   // Service is behind auth proxy, which authenticate user and set X-Forwarded-User header.
+}
+
+// NewControlAuth return authorization with token from file tokenFile (empty for no token).
+func NewControlAuth(tokenFile string, trustedProxy bool) (ControlAuth, error) {
+	result := ControlAuth{TrustedProxy: trustedProxy}
+
+	if tokenFile == "" {
+		return result, nil
+	}
+
+	data, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return result, fmt.Errorf("read control token file: %w", err)
+	}
+
+	result.Token = strings.TrimSpace(string(data))
+	if result.Token == "" {
+		return result, fmt.Errorf("control token file %v is empty", tokenFile)
+	}
+
+	return result, nil
+}
+
+// SetControlAuth set authorization of control API, must be called before Start.
+func (o *Runner) SetControlAuth(auth ControlAuth) {
+	o.auth = auth
+}
+
+// control return handler, which pass GET and HEAD requests and authorized mutating requests to next.
+func (o *Runner) control(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			if code, err := o.auth.authorize(r); err != nil {
+				o.logger.Sugar().Warnf("Rejected %v %v from %v: %v", r.Method, r.URL.Path, r.RemoteAddr, err)
+				http.Error(w, err.Error(), code)
+
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authorize check mutating request, return HTTP code and error for rejected request.
+func (o ControlAuth) authorize(r *http.Request) (int, error) {
+	switch {
+	case o.Token != "":
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(o.Token)) != 1 {
+			return http.StatusUnauthorized, fmt.Errorf("invalid or missing bearer token")
+		}
+	case o.TrustedProxy:
+		if r.Header.Get("X-Forwarded-User") == "" {
+			return http.StatusUnauthorized, fmt.Errorf("X-Forwarded-User header of auth proxy required")
+		}
+	default:
+		return http.StatusForbidden, fmt.Errorf("control API is read-only, set control_token_file or trusted_proxy")
+	}
+
+	return 0, nil
+}
+
+// user return user of request: X-Forwarded-User header of trusted proxy, or header sent with bearer token
+// marked as unverified with remote address, or remote address.
+func (o ControlAuth) user(r *http.Request) string {
+	user := r.Header.Get("X-Forwarded-User")
+	if o.TrustedProxy && user != "" {
+		return user
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	if o.Token != "" && user != "" {
+		return fmt.Sprintf("%v (unverified, %v)", user, host)
+	}
+
+	return host
+}
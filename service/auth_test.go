@@ -0,0 +1,114 @@
+package service_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/service"
+	"github.com/Volkov-Stanislav/silences-sheduler/stats"
+	"go.uber.org/zap"
+)
+
+func TestRunner_ControlAuth(t *testing.T) {
+	tests := []struct {
+		name     string
+		auth     service.ControlAuth
+		header   map[string]string
+		wantCode int
+		wantBy   string
+	}{
+		{
+			name:     "Read-only without token and proxy",
+			header:   map[string]string{"X-Forwarded-User": "ops"},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "Missing token",
+			auth:     service.ControlAuth{Token: "secret"},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "Wrong token",
+			auth:     service.ControlAuth{Token: "secret"},
+			header:   map[string]string{"Authorization": "Bearer guess"},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "Token, user header is unverified",
+			auth:     service.ControlAuth{Token: "secret"},
+			header:   map[string]string{"Authorization": "Bearer secret", "X-Forwarded-User": "admin"},
+			wantCode: http.StatusOK,
+			wantBy:   "admin (unverified, 192.0.2.1)",
+		},
+		{
+			name:     "Token without user header",
+			auth:     service.ControlAuth{Token: "secret"},
+			header:   map[string]string{"Authorization": "Bearer secret"},
+			wantCode: http.StatusOK,
+			wantBy:   "192.0.2.1",
+		},
+		{
+			name:     "Trusted proxy without user",
+			auth:     service.ControlAuth{TrustedProxy: true},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "Trusted proxy",
+			auth:     service.ControlAuth{TrustedProxy: true},
+			header:   map[string]string{"X-Forwarded-User": "ops"},
+			wantCode: http.StatusOK,
+			wantBy:   "ops",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+
+			runner, err := service.NewRunner(nil, nil, "", logger, stats.NewInstance("0", logger), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			runner.SetControlAuth(tt.auth)
+			runner.Start()
+			defer runner.Stop()
+
+			section := models.SheduleSection{Shedules: []models.Shedule{{Cron: "0 0 22 * * *", Duration: models.Duration(time.Hour)}}}
+			section.SetSectionName("night.yaml")
+			section.SetToken("night")
+			load(runner, nil, section)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/pause?section=night.yaml&user=admin", nil)
+
+			for name, value := range tt.header {
+				req.Header.Set(name, value)
+			}
+
+			runner.PauseHandler(false).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("pause status = %v, want %v, body %v", rec.Code, tt.wantCode, rec.Body)
+			}
+
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			var pause models.Pause
+
+			if err := json.NewDecoder(rec.Body).Decode(&pause); err != nil {
+				t.Fatal(err)
+			}
+
+			if pause.By != tt.wantBy {
+				t.Errorf("pause by %q, want %q", pause.By, tt.wantBy)
+			}
+		})
+	}
+}
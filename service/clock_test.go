@@ -0,0 +1,175 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/clock"
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/service"
+	"github.com/Volkov-Stanislav/silences-sheduler/stats"
+	"go.uber.org/zap"
+)
+
+// virtualRunner return started runner with virtual clock at now.
+func virtualRunner(t *testing.T, now time.Time) (*service.Runner, *clock.Fake, *fakeSink) {
+	logger := zap.NewNop()
+	sink := &fakeSink{}
+	fake := clock.NewFake(now)
+
+	runner, err := service.NewRunner(map[string]models.Sink{models.DefaultSink: sink}, nil, "", logger,
+		stats.NewInstance("0", logger), prom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner.SetClock(fake)
+	runner.Start()
+	t.Cleanup(runner.Stop)
+
+	return runner, fake, sink
+}
+
+// load sections into runner and wait until runner handle them.
+func load(runner *service.Runner, tokens []string, sections ...models.SheduleSection) {
+	add, del := runner.GetChannels()
+
+	for _, token := range tokens {
+		del <- token
+	}
+
+	for _, section := range sections {
+		add <- section
+	}
+
+	// runner receive next message only after all previous are handled.
+	del <- ""
+}
+
+// waitCreated wait for count silences created in sink.
+func waitCreated(t *testing.T, sink *fakeSink, count int) []models.Window {
+	t.Helper()
+
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if windows := sink.created(); len(windows) >= count {
+			return windows
+		}
+	}
+
+	t.Fatalf("created %v silences, want %v", len(sink.created()), count)
+
+	return nil
+}
+
+func virtualSection(token, offset string, shed models.Shedule) models.SheduleSection {
+	shed.Silence.Matchers = []models.Matchers{{IsEqual: true, Name: "section", Value: token}}
+	section := models.SheduleSection{TimeOffset: offset, Shedules: []models.Shedule{shed}}
+	section.SetSectionName(token + ".yaml")
+	section.SetToken(token)
+
+	return section
+}
+
+func TestRunner_VirtualWindows(t *testing.T) {
+	tests := []struct {
+		name      string
+		offset    string
+		shedule   models.Shedule
+		fire      time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name:      "Duration in positive offset",
+			offset:    "+3",
+			shedule:   models.Shedule{Cron: "0 0 1 * * *", Duration: models.Duration(2 * time.Hour)},
+			fire:      time.Date(2026, 11, 2, 22, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2026, 11, 2, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "Until next day in negative offset",
+			offset:    "-5",
+			shedule:   models.Shedule{Cron: "0 0 22 * * *", Until: "06:00"},
+			fire:      time.Date(2026, 11, 3, 3, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2026, 11, 3, 3, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 11, 3, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "One-off window",
+			offset:    "+3",
+			shedule:   models.Shedule{Start: "2026-11-03T10:00", End: "2026-11-03T14:00"},
+			fire:      time.Date(2026, 11, 3, 7, 0, 0, 0, time.UTC),
+			wantStart: time.Date(2026, 11, 3, 7, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2026, 11, 3, 11, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, fake, sink := virtualRunner(t, tt.fire.Add(-time.Minute))
+			load(runner, nil, virtualSection("night", tt.offset, tt.shedule))
+
+			fake.Advance(time.Minute - time.Second)
+
+			if windows := sink.created(); len(windows) != 0 {
+				t.Fatalf("silence created before shedule time: %v", windows[0].Start)
+			}
+
+			fake.Advance(time.Second)
+
+			window := waitCreated(t, sink, 1)[0]
+			if !window.Start.Equal(tt.wantStart) || !window.End.Equal(tt.wantEnd) {
+				t.Errorf("window = %v - %v, want %v - %v", window.Start, window.End, tt.wantStart, tt.wantEnd)
+			}
+
+			if !window.Silence.EndsAt.Equal(tt.wantEnd) {
+				t.Errorf("silence EndsAt = %v, want %v", window.Silence.EndsAt, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestRunner_VirtualReload(t *testing.T) {
+	start := time.Date(2026, 11, 3, 0, 59, 0, 0, time.UTC)
+	runner, fake, sink := virtualRunner(t, start)
+
+	hourly := models.Shedule{Cron: "0 0 * * * *", Duration: models.Duration(time.Minute)}
+	halfPast := models.Shedule{Cron: "0 30 * * * *", Duration: models.Duration(time.Minute)}
+
+	load(runner, nil, virtualSection("a", "UTC", hourly), virtualSection("b", "UTC", hourly))
+
+	// reload of a with new shedule does not touch b.
+	load(runner, []string{"a"}, virtualSection("a2", "UTC", halfPast))
+
+	fake.Advance(time.Minute)
+
+	windows := waitCreated(t, sink, 1)
+	if value := windows[0].Silence.Matchers[0].Value; value != "b" {
+		t.Errorf("silence of section %v created at 01:00, want b", value)
+	}
+
+	fake.Advance(30 * time.Minute)
+
+	windows = waitCreated(t, sink, 2)
+	if value := windows[1].Silence.Matchers[0].Value; value != "a2" || !windows[1].Start.Equal(start.Add(31*time.Minute)) {
+		t.Errorf("silence of section %v created at %v, want a2 at 01:30", value, windows[1].Start)
+	}
+
+	// removed section is not activated.
+	load(runner, []string{"b"})
+	fake.Advance(30 * time.Minute)
+
+	windows = waitCreated(t, sink, 2)
+	time.Sleep(50 * time.Millisecond)
+
+	if got := len(sink.created()); got != 2 {
+		t.Errorf("created %v silences after removal of b, want 2", got)
+	}
+
+	for _, window := range windows {
+		if window.Silence.Matchers[0].Value == "a" {
+			t.Error("silence of reloaded section a created")
+		}
+	}
+}
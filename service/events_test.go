@@ -0,0 +1,97 @@
+package service_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/clock"
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/service"
+	"github.com/Volkov-Stanislav/silences-sheduler/stats"
+	"go.uber.org/zap"
+)
+
+// fakeNotifier record sent events.
+type fakeNotifier struct {
+	mux    sync.Mutex
+	events []models.Event
+}
+
+func (o *fakeNotifier) Notify(e models.Event) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	o.events = append(o.events, e)
+}
+
+// types return types of sent events.
+func (o *fakeNotifier) types() []models.EventType {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	result := make([]models.EventType, 0, len(o.events))
+	for _, e := range o.events {
+		result = append(result, e.Type)
+	}
+
+	return result
+}
+
+// waitEvents wait for count events sent by notifier.
+func waitEvents(t *testing.T, notifier *fakeNotifier, count int) []models.EventType {
+	t.Helper()
+
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if types := notifier.types(); len(types) >= count {
+			return types
+		}
+	}
+
+	t.Fatalf("sent events %v, want %v events", notifier.types(), count)
+
+	return nil
+}
+
+func TestRunner_ExpiredEvent(t *testing.T) {
+	logger := zap.NewNop()
+	notifier := &fakeNotifier{}
+	fire := time.Date(2026, 11, 3, 1, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(fire.Add(-time.Minute))
+
+	runner, err := service.NewRunner(map[string]models.Sink{models.DefaultSink: &fakeSink{}}, notifier, "", logger,
+		stats.NewInstance("0", logger), prom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner.SetClock(fake)
+	runner.Start()
+	t.Cleanup(runner.Stop)
+
+	section := virtualSection("night", "UTC", models.Shedule{Cron: "0 0 1 * * *", Duration: models.Duration(time.Hour)})
+	section.Webhooks = []models.WebhookTarget{{URL: "http://hooks.local/silences"}}
+	load(runner, nil, section)
+
+	fake.Advance(time.Minute)
+
+	if types := waitEvents(t, notifier, 1); types[0] != models.EventCreated {
+		t.Fatalf("events = %v, want created", types)
+	}
+
+	// section removed before end of silence, silence is still active until its end.
+	load(runner, []string{"night"})
+
+	fake.Advance(time.Hour - time.Second)
+	time.Sleep(50 * time.Millisecond)
+
+	if types := notifier.types(); len(types) != 1 {
+		t.Fatalf("events before end of silence = %v, want [created]", types)
+	}
+
+	fake.Advance(time.Second)
+
+	if types := waitEvents(t, notifier, 2); types[1] != models.EventExpired {
+		t.Errorf("events = %v, want [created expired]", types)
+	}
+}
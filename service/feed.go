@@ -0,0 +1,125 @@
+package service
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/ical"
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/datadog/mmh3"
+)
+
+const (
+	// feedWeeks default count of weeks in calendar feed.
+	feedWeeks = 4
+	// feedMaxWeeks max count of weeks in calendar feed.
+	feedMaxWeeks = 52
+)
+
+// ServeCalendar write upcoming silence windows of all runned shedules as iCalendar feed.
+// Query parameters: weeks - count of weeks from now (default 4), section - name or file of section,
+// label - name=value of equal matcher. section and label may be repeated, any of values match.
+func (o *Runner) ServeCalendar(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	weeks := feedWeeks
+
+	if value := query.Get("weeks"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 || n > feedMaxWeeks {
+			http.Error(w, fmt.Sprintf("weeks must be from 1 to %v", feedMaxWeeks), http.StatusBadRequest)
+			return
+		}
+
+		weeks = n
+	}
+
+	labels := make([]models.Matchers, 0, len(query["label"]))
+
+	for _, label := range query["label"] {
+		eq := strings.Index(label, "=")
+		if eq <= 0 {
+			http.Error(w, fmt.Sprintf("label %q must be name=value", label), http.StatusBadRequest)
+			return
+		}
+
+		labels = append(labels, models.Matchers{IsEqual: true, Name: label[:eq], Value: label[eq+1:]})
+	}
+
+	from := o.now()
+	to := from.AddDate(0, 0, 7*weeks)
+
+	var events []ical.Event
+
+	o.mux.RLock()
+	for _, section := range o.sheds {
+		if !matchSection(section, query["section"]) {
+			continue
+		}
+
+		for _, window := range section.Upcoming(&o.env, from, to, 0) {
+			if matchLabels(window.Silence.Matchers, labels) {
+				events = append(events, windowEvent(window))
+			}
+		}
+	}
+	o.mux.RUnlock()
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+
+	if err := ical.Write(w, "Silences", events); err != nil {
+		o.logger.Sugar().Errorf("write in http.ResponseWriter failed: error %v", err)
+	}
+}
+
+// matchSection check if section has one of names or file paths, empty names match all sections.
+func matchSection(section *models.SheduleSection, names []string) bool {
+	if len(names) == 0 {
+		return true
+	}
+
+	for _, name := range names {
+		if name == section.GetSectionName() || name == section.GetFilePath() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchLabels check if matchers contain one of equal labels, empty labels match all.
+func matchLabels(matchers, labels []models.Matchers) bool {
+	if len(labels) == 0 {
+		return true
+	}
+
+	for _, label := range labels {
+		for _, matcher := range matchers {
+			if matcher.IsEqual && !matcher.IsRegex && matcher.Name == label.Name && matcher.Value == label.Value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// windowEvent convert silence window to calendar event.
+func windowEvent(window models.Window) ical.Event {
+	section := window.Section.GetSectionName()
+	uid := fmt.Sprintf("%v|%v|%v", window.Shedule.Key(), window.Start.Unix(), window.End.Unix())
+
+	description := fmt.Sprintf("Section: %v\nShedule: %v\nTenant: %v\nComment: %v\nCreated by: %v\nMatchers: %v",
+		section, window.Shedule.Spec(), window.Tenant, window.Silence.Comment, window.Silence.CreatedBy, window.Silence.Matchers)
+
+	return ical.Event{
+		UID:         hex.EncodeToString(mmh3.Hash128([]byte(uid)).Bytes()) + "@silences-sheduler",
+		Summary:     fmt.Sprintf("Silence: %v (%v)", window.Silence.Comment, section),
+		Description: description,
+		Start:       window.Start,
+		End:         window.End,
+	}
+}
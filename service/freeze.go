@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+const (
+	// freezeCheckInterval interval of check of freeze sentinel file.
+	freezeCheckInterval = 10 * time.Second
+	// freezeExpireTimeout timeout of expiring of active silences on freeze.
+	freezeExpireTimeout = time.Minute
+)
+
+// SetFreeze set global freeze switch, expire - expire silences created by scheduler when freeze begin.
+// Must be called before Start.
+func (o *Runner) SetFreeze(freeze *models.Freeze, expire bool) {
+	o.env.Freeze = freeze
+	o.freezeExpire = expire
+}
+
+// FreezeHandler return handler of freeze: GET return state, POST freeze (reason=<text>, expire=true|false
+// to expire active silences created by scheduler), DELETE clear freeze set by API.
+// POST and DELETE are authorized by control API auth.
+func (o *Runner) FreezeHandler() http.Handler {
+	return o.control(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			expire := o.freezeExpire
+
+			if value := r.URL.Query().Get("expire"); value != "" {
+				var err error
+
+				if expire, err = strconv.ParseBool(value); err != nil {
+					http.Error(w, "invalid expire "+value, http.StatusBadRequest)
+					return
+				}
+			}
+
+			by := o.auth.user(r)
+			o.env.Freeze.Set(by, r.URL.Query().Get("reason"), o.now())
+			o.logger.Sugar().Warnf("Freeze set by %v: %v", by, r.URL.Query().Get("reason"))
+			o.checkFreeze()
+
+			if expire {
+				o.expireActive()
+			}
+		case http.MethodDelete:
+			o.env.Freeze.Clear()
+			o.logger.Sugar().Warnf("Freeze by API cleared by %v", o.auth.user(r))
+			o.checkFreeze()
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		o.writeJSON(w, o.env.Freeze.State())
+	}))
+}
+
+// checkFreeze update freeze gauge, log changes of freeze state and expire active silences on begin of freeze,
+// if it is configured.
+func (o *Runner) checkFreeze() {
+	state := o.env.Freeze.State()
+
+	o.mux.Lock()
+	changed := state.Frozen != o.frozen
+	o.frozen = state.Frozen
+	o.mux.Unlock()
+
+	if o.prom != nil {
+		value := 0.0
+		if state.Frozen {
+			value = 1
+		}
+
+		o.prom.SetFreeze(value)
+	}
+
+	if !changed {
+		return
+	}
+
+	if !state.Frozen {
+		o.logger.Sugar().Warnf("Freeze ended, shedules create silences")
+		return
+	}
+
+	o.logger.Sugar().Warnf("Freeze begin by %v %v: %v, shedules don't create silences", state.Source, state.By, state.Reason)
+
+	if o.freezeExpire && state.Source != models.FreezeAPI {
+		go o.expireActive()
+	}
+}
+
+// expireActive expire all active silences created by scheduler.
+func (o *Runner) expireActive() {
+	ctx, cancel := context.WithTimeout(context.Background(), freezeExpireTimeout)
+	defer cancel()
+
+	for _, active := range o.env.Active.List(o.now()) {
+		if err := o.env.Expire(ctx, active); err != nil {
+			o.logger.Sugar().Errorf("Error expire silence %v in %v on freeze: %v", active.ID, active.Sink, err)
+			continue
+		}
+
+		o.logger.Sugar().Infof("Expired silence %v in %v on freeze", active.ID, active.Sink)
+	}
+}
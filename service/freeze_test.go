@@ -0,0 +1,191 @@
+package service_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/clock"
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/service"
+	"github.com/Volkov-Stanislav/silences-sheduler/stats"
+	"go.uber.org/zap"
+)
+
+func TestRunner_Freeze(t *testing.T) {
+	logger := zap.NewNop()
+	sink := &fakeSink{}
+	sentinel := filepath.Join(t.TempDir(), models.FreezeFileName)
+
+	runner, err := service.NewRunner(map[string]models.Sink{models.DefaultSink: sink}, nil, "", logger,
+		stats.NewInstance("0", logger), prom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner.SetFreeze(models.NewFreeze(false, sentinel), false)
+	runner.SetControlAuth(service.ControlAuth{Token: "secret"})
+	runner.Start()
+	defer runner.Stop()
+
+	section := models.SheduleSection{Shedules: []models.Shedule{{
+		Cron:     "0 0 3 1 1 *",
+		Duration: models.Duration(time.Hour),
+		Silence:  models.Silence{Matchers: []models.Matchers{{IsEqual: true, Name: "service", Value: "db"}}},
+	}}}
+	section.SetSectionName("night.yaml")
+	section.SetToken("night")
+
+	add, _ := runner.GetChannels()
+	add <- section
+
+	id := runner.Preview("night.yaml", time.Now(), time.Now().AddDate(1, 0, 1), 1)[0].ID
+
+	if err := os.WriteFile(sentinel, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runner.RunNow(id, "ops"); err != nil {
+		t.Fatal(err)
+	}
+
+	if created := sink.created(); len(created) != 0 {
+		t.Fatalf("created windows during freeze by file = %v", created)
+	}
+
+	if err := os.Remove(sentinel); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runner.RunNow(id, "ops"); err != nil {
+		t.Fatal(err)
+	}
+
+	if created := sink.created(); len(created) != 1 {
+		t.Fatalf("created windows after freeze = %v, want 1", created)
+	}
+
+	handler := runner.FreezeHandler()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/freeze?reason=incident", nil))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("freeze without token status = %v, want %v", rec.Code, http.StatusUnauthorized)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/freeze", nil))
+
+	if !strings.Contains(rec.Body.String(), `"frozen":false`) {
+		t.Fatalf("state after freeze without token = %v", rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, authorized(httptest.NewRequest(http.MethodPost, "/freeze?reason=incident&expire=true", nil)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("freeze status = %v, body %v", rec.Code, rec.Body)
+	}
+
+	sink.mux.Lock()
+	expired := sink.expired
+	sink.mux.Unlock()
+
+	if len(expired) != 1 {
+		t.Errorf("expired silences on freeze = %v, want 1", expired)
+	}
+
+	if err := runner.RunNow(id, "ops"); err != nil {
+		t.Fatal(err)
+	}
+
+	if created := sink.created(); len(created) != 1 {
+		t.Errorf("created windows during freeze by API = %v", created)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, authorized(httptest.NewRequest(http.MethodDelete, "/freeze", nil)))
+
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"frozen":false`) {
+		t.Errorf("unfreeze = %v %v", rec.Code, rec.Body)
+	}
+}
+
+// authorized set bearer token "secret" of control API in request.
+func authorized(req *http.Request) *http.Request {
+	req.Header.Set("Authorization", "Bearer secret")
+	return req
+}
+
+func TestRunner_FreezeSinceVirtualTime(t *testing.T) {
+	logger := zap.NewNop()
+	now := time.Date(2026, 11, 3, 1, 0, 0, 0, time.UTC)
+
+	runner, err := service.NewRunner(nil, nil, "", logger, stats.NewInstance("0", logger), prom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner.SetClock(clock.NewFake(now))
+	runner.SetControlAuth(service.ControlAuth{Token: "secret"})
+	runner.Start()
+	defer runner.Stop()
+
+	rec := httptest.NewRecorder()
+	runner.FreezeHandler().ServeHTTP(rec, authorized(httptest.NewRequest(http.MethodPost, "/freeze?reason=incident", nil)))
+
+	var state models.FreezeState
+
+	if err := json.NewDecoder(rec.Body).Decode(&state); err != nil {
+		t.Fatal(err)
+	}
+
+	if !state.Frozen || !state.Since.Equal(now) {
+		t.Errorf("freeze state = %+v, want frozen since %v", state, now)
+	}
+}
+
+func TestRunner_FreezeFileByVirtualTime(t *testing.T) {
+	logger := zap.NewNop()
+	sink := &fakeSink{}
+	sentinel := filepath.Join(t.TempDir(), models.FreezeFileName)
+	start := time.Date(2026, 11, 3, 10, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start.Add(time.Hour))
+
+	runner, err := service.NewRunner(map[string]models.Sink{models.DefaultSink: sink}, nil, "", logger,
+		stats.NewInstance("0", logger), prom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner.SetClock(fake)
+	runner.SetFreeze(models.NewFreeze(false, sentinel), true)
+	runner.Start()
+	defer runner.Stop()
+
+	load(runner, nil, virtualSection("once", "UTC", models.Shedule{Start: "2026-11-03T10:00", End: "2026-11-03T14:00"}))
+	waitCreated(t, sink, 1)
+
+	if err := os.WriteFile(sentinel, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fake.Advance(10 * time.Second)
+
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		sink.mux.Lock()
+		expired := len(sink.expired)
+		sink.mux.Unlock()
+
+		if expired == 1 {
+			return
+		}
+	}
+
+	t.Error("active silence not expired after freeze file check by virtual clock")
+}
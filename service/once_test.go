@@ -0,0 +1,173 @@
+package service_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/clock"
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/service"
+	"github.com/Volkov-Stanislav/silences-sheduler/stats"
+	"go.uber.org/zap"
+)
+
+// persistentRunner return started runner with virtual clock at now, sink and registry of active silences in file path.
+func persistentRunner(t *testing.T, now time.Time, path string, sink models.Sink) (*service.Runner, *clock.Fake) {
+	logger := zap.NewNop()
+	fake := clock.NewFake(now)
+
+	active, err := models.NewActiveSilences(path, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner, err := service.NewRunner(map[string]models.Sink{models.DefaultSink: sink}, nil, "", logger,
+		stats.NewInstance("0", logger), prom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner.SetClock(fake)
+	runner.SetActive(active)
+	runner.Start()
+
+	return runner, fake
+}
+
+// assertCreated check count of silences created in sink after runner handled all jobs.
+func assertCreated(t *testing.T, sink *fakeSink, count int) {
+	t.Helper()
+
+	time.Sleep(50 * time.Millisecond)
+
+	if got := len(sink.created()); got != count {
+		t.Errorf("created %v silences, want %v", got, count)
+	}
+}
+
+func TestRunner_OneOff(t *testing.T) {
+	start := time.Date(2026, 11, 3, 10, 0, 0, 0, time.UTC)
+	once := models.Shedule{Start: "2026-11-03T10:00", End: "2026-11-03T14:00"}
+
+	t.Run("In progress, reload", func(t *testing.T) {
+		runner, fake, sink := virtualRunner(t, start.Add(time.Hour))
+		load(runner, nil, virtualSection("once", "UTC", once))
+		waitCreated(t, sink, 1)
+
+		fake.Advance(time.Hour)
+		load(runner, []string{"once"}, virtualSection("once", "UTC", once))
+		assertCreated(t, sink, 1)
+	})
+
+	t.Run("In progress, restart", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "active.json")
+
+		sink := &fakeSink{}
+		runner, _ := persistentRunner(t, start.Add(time.Hour), path, sink)
+		load(runner, nil, virtualSection("once", "UTC", once))
+		waitCreated(t, sink, 1)
+		runner.Stop()
+
+		sink = &fakeSink{}
+		runner, _ = persistentRunner(t, start.Add(2*time.Hour), path, sink)
+		t.Cleanup(runner.Stop)
+		load(runner, nil, virtualSection("once", "UTC", once))
+		assertCreated(t, sink, 0)
+	})
+
+	t.Run("Future, reload before and after start", func(t *testing.T) {
+		runner, fake, sink := virtualRunner(t, start.Add(-time.Hour))
+		load(runner, nil, virtualSection("once", "UTC", once))
+		assertCreated(t, sink, 0)
+
+		load(runner, []string{"once"}, virtualSection("once", "UTC", once))
+		fake.Advance(time.Hour)
+
+		window := waitCreated(t, sink, 1)[0]
+		if !window.Start.Equal(start) || !window.End.Equal(start.Add(4*time.Hour)) {
+			t.Errorf("window = %v - %v, want 10:00 - 14:00", window.Start, window.End)
+		}
+
+		load(runner, []string{"once"}, virtualSection("once", "UTC", once))
+		assertCreated(t, sink, 1)
+	})
+
+	t.Run("Expired, reload", func(t *testing.T) {
+		runner, _, sink := virtualRunner(t, start.Add(5*time.Hour))
+		load(runner, nil, virtualSection("once", "UTC", once))
+		load(runner, []string{"once"}, virtualSection("once", "UTC", once))
+		assertCreated(t, sink, 0)
+	})
+
+	t.Run("Changed end is new window", func(t *testing.T) {
+		runner, _, sink := virtualRunner(t, start.Add(time.Hour))
+		load(runner, nil, virtualSection("once", "UTC", once))
+		waitCreated(t, sink, 1)
+
+		longer := once
+		longer.End = "2026-11-03T16:00"
+		load(runner, []string{"once"}, virtualSection("once", "UTC", longer))
+		waitCreated(t, sink, 2)
+	})
+}
+
+// extendingSink fake sink, which extends silences.
+type extendingSink struct {
+	fakeSink
+	extended []models.Window
+}
+
+func (o *extendingSink) Extend(ctx context.Context, w models.Window, id string) (string, error) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	o.extended = append(o.extended, w)
+
+	return id, nil
+}
+
+func (o *extendingSink) extendedWindows() []models.Window {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	return append([]models.Window{}, o.extended...)
+}
+
+func TestRunner_ExtendAfterRestart(t *testing.T) {
+	start := time.Date(2026, 11, 3, 10, 0, 0, 0, time.UTC)
+	hourly := models.Shedule{Cron: "0 0 * * * *", Duration: models.Duration(2 * time.Hour)}
+	path := filepath.Join(t.TempDir(), "active.json")
+
+	sink := &extendingSink{}
+	runner, fake := persistentRunner(t, start.Add(-time.Minute), path, sink)
+	load(runner, nil, virtualSection("hourly", "UTC", hourly))
+	fake.BlockUntil(1)
+	fake.Advance(time.Minute)
+
+	startsAt := waitCreated(t, &sink.fakeSink, 1)[0].Silence.StartsAt
+	runner.Stop()
+
+	sink = &extendingSink{}
+	runner, fake = persistentRunner(t, start.Add(59*time.Minute), path, sink)
+	t.Cleanup(runner.Stop)
+	load(runner, nil, virtualSection("hourly", "UTC", hourly))
+	fake.BlockUntil(1)
+	fake.Advance(time.Minute)
+
+	for deadline := time.Now().Add(5 * time.Second); len(sink.extendedWindows()) == 0 && time.Now().Before(deadline); {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	extended := sink.extendedWindows()
+	if len(extended) != 1 {
+		t.Fatalf("extended %v silences, want 1", len(extended))
+	}
+
+	if !extended[0].Silence.StartsAt.Equal(startsAt) || !extended[0].End.Equal(start.Add(3*time.Hour)) {
+		t.Errorf("extended silence startsAt %v, end %v, want %v, %v", extended[0].Silence.StartsAt, extended[0].End, startsAt, start.Add(3*time.Hour))
+	}
+
+	assertCreated(t, &sink.fakeSink, 0)
+}
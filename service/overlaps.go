@@ -0,0 +1,54 @@
+package service
+
+import (
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+const (
+	// overlapDelay delay of overlap check after reload of sections, new reloads postpone check.
+	overlapDelay = 5 * time.Second
+	// OverlapHorizon horizon of overlap check.
+	OverlapHorizon = 28 * 24 * time.Hour
+)
+
+// scheduleOverlapCheck schedule overlap check of runned sections after overlapDelay.
+func (o *Runner) scheduleOverlapCheck() {
+	if o.overlap == nil {
+		o.overlap = time.AfterFunc(overlapDelay, o.checkOverlaps)
+		return
+	}
+
+	o.overlap.Reset(overlapDelay)
+}
+
+// checkOverlaps log overlaps of runned sections and update overlaps metrics.
+func (o *Runner) checkOverlaps() {
+	var stacking, overlaps float64
+
+	o.mux.RLock()
+	defer o.mux.RUnlock()
+
+	sections := make([]*models.SheduleSection, 0, len(o.sheds))
+	for _, section := range o.sheds {
+		sections = append(sections, section)
+	}
+
+	now := o.now()
+
+	for _, overlap := range models.FindOverlaps(&o.env, sections, now, now.Add(OverlapHorizon)) {
+		o.logger.Sugar().Warnf("Overlap: %v", overlap)
+
+		if overlap.Stacking {
+			stacking++
+		} else {
+			overlaps++
+		}
+	}
+
+	if o.prom != nil {
+		o.prom.SetOverlaps("overlap", overlaps)
+		o.prom.SetOverlaps("stacking", stacking)
+	}
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/utils"
+)
+
+// pauseCheckInterval interval of removing ended pauses.
+const pauseCheckInterval = time.Minute
+
+// PausesHandler return read-only handler of JSON list of active pauses: GET /pauses.
+func (o *Runner) PausesHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		o.writeJSON(w, o.env.Pauses.List(o.now()))
+	})
+}
+
+// PauseHandler return handler of pause and resume of section or shedule, authorized by control API auth.
+// POST /pause?section=<name> or ?id=<shedule ID>, optional until=<time or duration> for auto-resume and reason=<text>.
+// POST /resume with same section or id.
+func (o *Runner) PauseHandler(resume bool) http.Handler {
+	return o.control(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		kind, target, err := o.pauseTarget(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		if resume {
+			o.resume(w, r, kind, target)
+			return
+		}
+
+		now := o.now()
+		pause := models.Pause{
+			Kind:   kind,
+			Target: target,
+			Since:  now.UTC(),
+			By:     o.auth.user(r),
+			Reason: r.URL.Query().Get("reason"),
+		}
+
+		if until := r.URL.Query().Get("until"); until != "" {
+			if pause.Until, err = pauseUntil(until, now); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		if err := o.env.Pauses.Set(pause); err != nil {
+			o.logger.Sugar().Errorf("Error save pauses: %v", err)
+		}
+
+		o.logger.Sugar().Infof("Paused %v %v by %v until %v: %v", kind, target, pause.By, pause.Until, pause.Reason)
+		o.updatePausesMetrics()
+		o.writeJSON(w, pause)
+	}))
+}
+
+// resume remove pause of target.
+func (o *Runner) resume(w http.ResponseWriter, r *http.Request, kind models.PauseKind, target string) {
+	ok, err := o.env.Pauses.Remove(kind, target)
+	if err != nil {
+		o.logger.Sugar().Errorf("Error save pauses: %v", err)
+	}
+
+	if !ok {
+		http.Error(w, fmt.Sprintf("%v %v is not paused", kind, target), http.StatusNotFound)
+		return
+	}
+
+	o.logger.Sugar().Infof("Resumed %v %v by %v", kind, target, o.auth.user(r))
+	o.updatePausesMetrics()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// pauseTarget return kind and target of request, section or shedule must be loaded.
+func (o *Runner) pauseTarget(r *http.Request) (models.PauseKind, string, error) {
+	section, id := r.URL.Query().Get("section"), r.URL.Query().Get("id")
+
+	if section == "" && id == "" {
+		return "", "", fmt.Errorf("section or id parameter required")
+	}
+
+	if id != "" {
+		if _, ok := o.findSection(id); ok {
+			return models.PauseShedule, id, nil
+		}
+	}
+
+	o.mux.RLock()
+	defer o.mux.RUnlock()
+
+	for _, shed := range o.sheds {
+		if section != "" && shed.GetSectionName() == section {
+			return models.PauseSection, section, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("section %q or shedule %q not found", section, id)
+}
+
+// expirePauses remove ended pauses, so shedules resume automatically.
+func (o *Runner) expirePauses() {
+	expired, err := o.env.Pauses.Expire(o.now())
+	if err != nil {
+		o.logger.Sugar().Errorf("Error save pauses: %v", err)
+	}
+
+	for _, pause := range expired {
+		o.logger.Sugar().Infof("Resumed %v %v, pause ended at %v", pause.Kind, pause.Target, pause.Until)
+	}
+
+	if len(expired) > 0 {
+		o.updatePausesMetrics()
+	}
+}
+
+// updatePausesMetrics set count of active pauses by kind.
+func (o *Runner) updatePausesMetrics() {
+	if o.prom == nil {
+		return
+	}
+
+	count := map[models.PauseKind]float64{models.PauseSection: 0, models.PauseShedule: 0}
+	for _, pause := range o.env.Pauses.List(o.now()) {
+		count[pause.Kind]++
+	}
+
+	for kind, value := range count {
+		o.prom.SetPaused(string(kind), value)
+	}
+}
+
+// writeJSON write value as JSON reply.
+func (o *Runner) writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		o.logger.Sugar().Errorf("write in http.ResponseWriter failed: error %v", err)
+	}
+}
+
+// pauseUntil parse end of pause: time or date, or duration from now.
+func pauseUntil(value string, now time.Time) (time.Time, error) {
+	if d, err := models.ParseDuration(value); err == nil {
+		return now.Add(time.Duration(d)).UTC(), nil
+	}
+
+	until, err := utils.ParseTime(value, time.Local)
+	if err != nil {
+		return until, fmt.Errorf("invalid until %q, want time or duration", value)
+	}
+
+	if !until.After(now) {
+		return until, fmt.Errorf("until %q is in the past", value)
+	}
+
+	return until.UTC(), nil
+}
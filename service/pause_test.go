@@ -0,0 +1,134 @@
+package service_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/clock"
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/service"
+	"github.com/Volkov-Stanislav/silences-sheduler/stats"
+	"go.uber.org/zap"
+)
+
+func TestRunner_PauseHandler(t *testing.T) {
+	logger := zap.NewNop()
+
+	runner, err := service.NewRunner(nil, nil, "", logger, stats.NewInstance("0", logger), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner.SetControlAuth(service.ControlAuth{TrustedProxy: true})
+	runner.Start()
+	defer runner.Stop()
+
+	section := models.SheduleSection{Shedules: []models.Shedule{{Cron: "0 0 22 * * *", Duration: models.Duration(time.Hour)}}}
+	section.SetSectionName("night.yaml")
+	section.SetToken("night")
+
+	add, _ := runner.GetChannels()
+	add <- section
+
+	pause, resume, pauses := runner.PauseHandler(false), runner.PauseHandler(true), runner.PausesHandler()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/pause?section=night.yaml&until=2h&reason=migration", nil)
+	req.Header.Set("X-Forwarded-User", "ops")
+	pause.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("pause status = %v, body %v", rec.Code, rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	pauses.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pauses", nil))
+
+	var list []models.Pause
+
+	if err := json.NewDecoder(rec.Body).Decode(&list); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list) != 1 || list[0].By != "ops" || list[0].Reason != "migration" || list[0].Until.Sub(list[0].Since) != 2*time.Hour {
+		t.Errorf("pauses = %+v", list)
+	}
+
+	rec = httptest.NewRecorder()
+	pauses.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/pauses?section=night.yaml", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /pauses status = %v, want %v", rec.Code, http.StatusMethodNotAllowed)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/pause?section=unknown.yaml", nil)
+	req.Header.Set("X-Forwarded-User", "ops")
+	pause.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("pause of unknown section status = %v, want %v", rec.Code, http.StatusNotFound)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/resume?section=night.yaml", nil)
+	req.Header.Set("X-Forwarded-User", "ops")
+	resume.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("resume status = %v, want %v", rec.Code, http.StatusNoContent)
+	}
+}
+
+func TestRunner_ExpirePausesByVirtualTime(t *testing.T) {
+	logger := zap.NewNop()
+	now := time.Date(2026, 11, 3, 10, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "pauses.json")
+	fake := clock.NewFake(now)
+
+	pauses, err := models.NewPauses(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pauses.Set(models.Pause{Kind: models.PauseSection, Target: "night.yaml", Since: now, Until: now.Add(30 * time.Second)}); err != nil {
+		t.Fatal(err)
+	}
+
+	runner, err := service.NewRunner(nil, nil, "", logger, stats.NewInstance("0", logger), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner.SetClock(fake)
+	runner.SetPauses(pauses)
+	runner.Start()
+	defer runner.Stop()
+
+	load(runner, nil)
+	fake.Advance(time.Minute)
+
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var saved []models.Pause
+
+		if err := json.Unmarshal(data, &saved); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(saved) == 0 {
+			return
+		}
+	}
+
+	t.Error("ended pause not removed after check by virtual clock")
+}
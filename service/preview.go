@@ -0,0 +1,239 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+const (
+	// previewCount default count of windows in preview.
+	previewCount = 10
+	// previewHorizon default horizon of preview.
+	previewHorizon = 30 * 24 * time.Hour
+	// previewMaxBody max size of file posted for preview.
+	previewMaxBody = 10 << 20
+)
+
+// Occurrence one upcoming silence window of shedule, for preview.
+type Occurrence struct {
+	ID        string             `json:"id"`
+	Section   string             `json:"section"`
+	File      string             `json:"file"`
+	Shedule   string             `json:"shedule"`
+	Start     time.Time          `json:"start"`
+	End       time.Time          `json:"end"`
+	Zone      string             `json:"zone"`
+	Tenant    string             `json:"tenant"`
+	Comment   string             `json:"comment"`
+	CreatedBy string             `json:"createdBy"`
+	Matchers  models.MatcherList `json:"matchers"`
+}
+
+// String format occurrence as line of text.
+func (o Occurrence) String() string {
+	return fmt.Sprintf("%v;%v - %v %v;%v;%v;%v;%v", o.ID, o.Start.Format("2006-01-02 15:04:05"), o.End.Format("2006-01-02 15:04:05"),
+		o.Zone, o.Section, o.Shedule, o.Comment, o.Matchers)
+}
+
+// FileLoader parse sections from file, storage selected by file extension.
+type FileLoader func(fileName string) ([]models.SheduleSection, error)
+
+// Preview return first count upcoming windows of sections from from to to, sorted by start.
+// Exclusion calendars are taken from sections and from env, env may be nil.
+func Preview(env *models.Environment, sections []*models.SheduleSection, from, to time.Time, count int) []Occurrence {
+	var windows []models.Window
+
+	for _, section := range sections {
+		windows = append(windows, section.Upcoming(env, from, to, count)...)
+	}
+
+	sort.SliceStable(windows, func(i, j int) bool {
+		return windows[i].Start.Before(windows[j].Start)
+	})
+
+	if count > 0 && len(windows) > count {
+		windows = windows[:count]
+	}
+
+	result := make([]Occurrence, 0, len(windows))
+
+	for _, window := range windows {
+		zone, _ := window.Start.Zone()
+		result = append(result, Occurrence{
+			ID:        window.Shedule.ID(),
+			Section:   window.Section.GetSectionName(),
+			File:      window.Section.GetFilePath(),
+			Shedule:   window.Shedule.Spec(),
+			Start:     window.Start,
+			End:       window.End,
+			Zone:      zone,
+			Tenant:    window.Tenant,
+			Comment:   window.Silence.Comment,
+			CreatedBy: window.Silence.CreatedBy,
+			Matchers:  window.Silence.Matchers,
+		})
+	}
+
+	return result
+}
+
+// PreviewFile return upcoming windows of sections from file, which is not loaded by storages.
+func PreviewFile(load FileLoader, fileName string, from, to time.Time, count int) ([]Occurrence, error) {
+	sections, err := load(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*models.SheduleSection, 0, len(sections))
+
+	for key := range sections {
+		if errs := sections[key].LoadCalendars(from); len(errs) > 0 {
+			return nil, errs[0]
+		}
+
+		list = append(list, &sections[key])
+	}
+
+	return Preview(nil, list, from, to, count), nil
+}
+
+// Preview return upcoming windows of runned sections with name or file path section, all sections if section is empty.
+func (o *Runner) Preview(section string, from, to time.Time, count int) []Occurrence {
+	var names []string
+	if section != "" {
+		names = append(names, section)
+	}
+
+	o.mux.RLock()
+	defer o.mux.RUnlock()
+
+	var list []*models.SheduleSection
+
+	for _, shed := range o.sheds {
+		if matchSection(shed, names) {
+			list = append(list, shed)
+		}
+	}
+
+	return Preview(&o.env, list, from, to, count)
+}
+
+// PreviewHandler return handler of JSON preview of upcoming windows.
+// GET preview runned sections, POST preview file in request body, type of file set by "type" parameter (yaml, csv, ics).
+// POST is authorized as control request, ics files of calendars are not allowed in posted file.
+// Query parameters: section - name or file of runned section, count - count of windows (default 10),
+// horizon - duration from now (default 720h).
+func (o *Runner) PreviewHandler(load FileLoader) http.Handler {
+	return o.control(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		count, horizon, err := previewParams(query.Get("count"), query.Get("horizon"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		from := o.now()
+
+		var result []Occurrence
+
+		switch r.Method {
+		case http.MethodGet:
+			result = o.Preview(query.Get("section"), from, from.Add(horizon), count)
+		case http.MethodPost:
+			result, err = o.previewBody(load, r.Body, query.Get("type"), from, from.Add(horizon), count)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(result); err != nil {
+			o.logger.Sugar().Errorf("write in http.ResponseWriter failed: error %v", err)
+		}
+	}))
+}
+
+// previewBody save posted file to temporary file with extension of fileType and preview it.
+func (o *Runner) previewBody(load FileLoader, body io.Reader, fileType string, from, to time.Time, count int) ([]Occurrence, error) {
+	if fileType == "" {
+		fileType = "yaml"
+	}
+
+	file, err := os.CreateTemp("", "preview-*."+fileType)
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.Remove(file.Name())
+
+	_, err = io.Copy(file, io.LimitReader(body, previewMaxBody))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return PreviewFile(postedLoader(load), file.Name(), from, to, count)
+}
+
+// postedLoader return loader of posted file, which reject calendars with ics files:
+// posted file must not read files of host.
+func postedLoader(load FileLoader) FileLoader {
+	return func(fileName string) ([]models.SheduleSection, error) {
+		sections, err := load(fileName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, section := range sections {
+			for name, calendar := range section.Calendars {
+				if calendar != nil && calendar.ICS != "" {
+					return nil, fmt.Errorf("calendar %v: ics files are not allowed in posted file", name)
+				}
+			}
+		}
+
+		return sections, nil
+	}
+}
+
+// previewParams parse count and horizon of preview, empty values are defaults.
+func previewParams(count, horizon string) (int, time.Duration, error) {
+	n := previewCount
+	d := previewHorizon
+
+	if count != "" {
+		var err error
+
+		if n, err = strconv.Atoi(count); err != nil || n <= 0 {
+			return 0, 0, fmt.Errorf("invalid count %q", count)
+		}
+	}
+
+	if horizon != "" {
+		value, err := models.ParseDuration(horizon)
+		if err != nil || value <= 0 {
+			return 0, 0, fmt.Errorf("invalid horizon %q", horizon)
+		}
+
+		d = time.Duration(value)
+	}
+
+	return n, d, nil
+}
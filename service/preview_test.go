@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/service"
+	"github.com/Volkov-Stanislav/silences-sheduler/storages"
+	"go.uber.org/zap"
+)
+
+func TestRunner_PreviewHandler(t *testing.T) {
+	logger := zap.NewNop()
+	config := map[string]string{"shedules_dir": t.TempDir(), "update_interval": "60"}
+
+	runner, err := service.NewRunner(nil, nil, "", logger, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner.SetControlAuth(service.ControlAuth{Token: "secret"})
+
+	handler := runner.PreviewHandler(func(fileName string) ([]models.SheduleSection, error) {
+		return storages.ReadFile(fileName, config, logger)
+	})
+
+	body := `timeoffset: "+3"
+shedules:
+  - cron: "0 0 22 * * *"
+    until: "06:00"
+    silence:
+      comment: "night {{.Vars.team}}"
+      matchers: [{isEqual: true, isRegex: false, name: service, value: db}]
+    vars: {team: dba}
+`
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/next?count=3&horizon=168h", strings.NewReader(body)))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("post without token status = %v, want %v", rec.Code, http.StatusUnauthorized)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, authorized(httptest.NewRequest(http.MethodPost, "/next?count=3&horizon=168h", strings.NewReader(body))))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %v, body %v", rec.Code, rec.Body)
+	}
+
+	var result []service.Occurrence
+
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result) != 3 {
+		t.Fatalf("occurrences = %v, want 3", len(result))
+	}
+
+	for _, occurrence := range result {
+		if occurrence.Comment != "night dba" || occurrence.End.Sub(occurrence.Start).Hours() != 8 || occurrence.Start.Hour() != 22 {
+			t.Errorf("occurrence = %+v", occurrence)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/next?horizon=never", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid horizon status = %v, want %v", rec.Code, http.StatusBadRequest)
+	}
+
+	secret := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(secret, []byte("s3cr3t-control-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	body = `calendars:
+  x: {ics: ` + secret + `}
+shedules:
+  - cron: "0 0 22 * * *"
+    duration: 1h
+    exclude: [x]
+    silence:
+      matchers: [{isEqual: true, isRegex: false, name: service, value: db}]
+`
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, authorized(httptest.NewRequest(http.MethodPost, "/next", strings.NewReader(body))))
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "not allowed") || strings.Contains(rec.Body.String(), "s3cr3t") {
+		t.Errorf("post with ics calendar status = %v, body %v, want %v without content of file", rec.Code, rec.Body, http.StatusBadRequest)
+	}
+}
@@ -1,9 +1,12 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/Volkov-Stanislav/silences-sheduler/clock"
 	"github.com/Volkov-Stanislav/silences-sheduler/metrics"
 	"github.com/Volkov-Stanislav/silences-sheduler/models"
 	"github.com/Volkov-Stanislav/silences-sheduler/stats"
@@ -11,42 +14,154 @@ import (
 )
 
 // Runner main service struct.
+// Sections are added and removed only by run gorutine under write lock of mux,
+// handlers and checks read sections under read lock.
 type Runner struct {
 	addShed chan models.SheduleSection
 	delShed chan string
 	stop    chan bool
+	done    chan struct{} // Closed when run exit.
+	cancel  context.CancelFunc
 	sheds   map[string]*models.SheduleSection
-	apiURL  string
+	tenant  string
 	logger  *zap.Logger
-	mux     sync.Mutex
+	mux     sync.RWMutex
 	stat    *stats.Instance
 	prom    *metrics.Instance
+	env     models.Environment
+	sched   *Scheduler  // Scheduler of shedules of all sections.
+	overlap *time.Timer // Timer of overlap check after reload, used only in run.
+	// freezeExpire expire active silences on begin of freeze, frozen is last checked state of freeze.
+	freezeExpire bool
+	frozen       bool
+	auth         ControlAuth // Authorization of control API.
+	// shutdown stop runner once, shutdownErr is result of first Shutdown.
+	shutdown    sync.Once
+	shutdownErr error
 }
 
 // NewRunner return configured Runner instance.
-func NewRunner(apiURL string, logger *zap.Logger, stat *stats.Instance, prom *metrics.Instance) (*Runner, error) {
+// sinks are targets for silences by name, notifier send silence lifecycle events (may be nil), tenant is default Alertmanager tenant for sections without own tenant.
+func NewRunner(sinks map[string]models.Sink, notifier models.Notifier, tenant string, logger *zap.Logger, stat *stats.Instance, prom *metrics.Instance) (*Runner, error) {
 	var o Runner
 	o.addShed = make(chan models.SheduleSection)
 	o.delShed = make(chan string)
 	o.stop = make(chan bool)
+	o.done = make(chan struct{})
 	o.sheds = make(map[string]*models.SheduleSection)
-	o.apiURL = apiURL
+	o.tenant = tenant
 	o.logger = logger
 	o.stat = stat
 	o.prom = prom
+	o.env = models.Environment{
+		Sinks:     sinks,
+		Logger:    logger,
+		Stat:      stat,
+		Prom:      prom,
+		Notifier:  notifier,
+		Calendars: models.NewCalendarSet(),
+	}
+	o.env.Context, o.cancel = context.WithCancel(context.Background())
+	o.SetClock(clock.System())
+	o.env.Active, _ = models.NewActiveSilences("", time.Time{})
+	o.env.Pauses, _ = models.NewPauses("")
+	o.env.Skips = models.NewSkips()
+	o.env.Freeze = models.NewFreeze(false, "")
+
+	if stat != nil {
+		stat.SetShedules(o.shedulesForWeb)
+	}
 
 	return &o, nil
 }
 
 // Start runner.
 func (o *Runner) Start() {
+	o.sched.Start()
 	go o.run()
 }
 
-// Stop Runner.
+// Stop Runner, waiting for running shedules.
 func (o *Runner) Stop() {
 	fmt.Println("Runner (o *Instance) Stop()")
+
+	if err := o.Shutdown(context.Background()); err != nil {
+		o.logger.Sugar().Errorf("Error stop runner: %v", err)
+	}
+}
+
+// Shutdown stop runner and scheduler of all sections, waiting for running shedules until ctx is done.
+// After that in-flight requests to sinks are cancelled. Next calls return result of first call.
+func (o *Runner) Shutdown(ctx context.Context) error {
+	o.shutdown.Do(func() {
+		o.shutdownErr = o.shutdownSections(ctx)
+	})
+
+	return o.shutdownErr
+}
+
+// shutdownSections stop run gorutine, scheduler and all sections, return error of first not stopped section.
+func (o *Runner) shutdownSections(ctx context.Context) error {
+	defer o.cancel()
+
 	o.stop <- true
+	<-o.done
+	o.sched.Stop()
+
+	o.mux.RLock()
+	sheds := make([]*models.SheduleSection, 0, len(o.sheds))
+
+	for _, shed := range o.sheds {
+		sheds = append(sheds, shed)
+	}
+	o.mux.RUnlock()
+
+	var (
+		first  error
+		failed int
+	)
+
+	for _, shed := range sheds {
+		if err := shed.Shutdown(ctx); err != nil {
+			if first == nil {
+				first = err
+			}
+			failed++
+		}
+	}
+
+	if failed > 1 {
+		return fmt.Errorf("%w (and %v more sections)", first, failed-1)
+	}
+
+	return first
+}
+
+// SetClock set source of current time and timers of scheduler, must be called before Start.
+func (o *Runner) SetClock(clk clock.Clock) {
+	o.env.Clock = clk
+	o.sched = NewScheduler(clk)
+	o.env.Scheduler = o.sched
+}
+
+// now return current time of clock of runner.
+func (o *Runner) now() time.Time {
+	return o.env.Clock.Now()
+}
+
+// SetPauses set registry of runtime pauses, must be called before Start.
+func (o *Runner) SetPauses(pauses *models.Pauses) {
+	o.env.Pauses = pauses
+}
+
+// SetActive set registry of silences created by scheduler, must be called before Start.
+func (o *Runner) SetActive(active *models.ActiveSilences) {
+	o.env.Active = active
+}
+
+// SetPolicy set policy of mandatory and forbidden matchers, must be called before Start.
+func (o *Runner) SetPolicy(policy *models.Policy) {
+	o.env.Policy = policy
 }
 
 // GetChannels return sync channels for  use on another gorutines for syncing.
@@ -55,47 +170,79 @@ func (o *Runner) GetChannels() (add chan models.SheduleSection, del chan string)
 }
 
 func (o *Runner) run() {
+	// timers of clock are single, they are created again after every check.
+	pauses := o.env.Clock.NewTimer(pauseCheckInterval)
+	freeze := o.env.Clock.NewTimer(freezeCheckInterval)
+
+	defer func() {
+		pauses.Stop()
+		freeze.Stop()
+	}()
+
+	o.updatePausesMetrics()
+	o.checkFreeze()
+
+	defer close(o.done)
+
 	for {
 		select {
+		case <-freeze.C():
+			o.checkFreeze()
+			freeze = o.env.Clock.NewTimer(freezeCheckInterval)
+		case <-pauses.C():
+			o.expirePauses()
+			pauses = o.env.Clock.NewTimer(pauseCheckInterval)
 		case <-o.stop:
-			// stop cron shedules.
-			for shedToken := range o.sheds {
-				fmt.Printf("Stop Cron Token: %v", shedToken)
-				o.sheds[shedToken].Stop()
+			// shedules are stopped by Shutdown.
+			if o.overlap != nil {
+				o.overlap.Stop()
 			}
 
 			return
-		case <-o.stat.RecvStat:
-			o.setShedulesForWeb()
-			o.stat.SetOK <- true
 		case shed := <-o.addShed:
 			token := shed.GetToken()
+			// default tenant is Mimir/Cortex tenant, it is not Grafana organization.
+			if shed.Tenant == "" && (shed.Sink == "" || shed.Sink == models.DefaultSink) {
+				shed.Tenant = o.tenant
+			}
 			o.logger.Info(fmt.Sprintf("Start shedules %v \n with token %v \n", shed, shed.GetToken()))
 			o.mux.Lock()
 			o.sheds[token] = &shed
-			o.sheds[token].Run(o.apiURL, o.logger, o.stat, o.prom)
+			o.sheds[token].Run(&o.env)
+			o.env.Calendars.Set(token, o.sheds[token].Calendars)
 			o.mux.Unlock()
+			o.scheduleOverlapCheck()
 		case token := <-o.delShed:
-			if _, ok := o.sheds[token]; ok {
-				o.logger.Info(fmt.Sprintf("Stop shedules %v \n with token %v \n", o.sheds[token], token))
-				o.mux.Lock()
-				o.sheds[token].Stop()
+			o.mux.Lock()
+			shed, ok := o.sheds[token]
+
+			if ok {
+				o.logger.Info(fmt.Sprintf("Stop shedules %v \n with token %v \n", shed, token))
+				shed.Stop()
+				o.env.Calendars.Remove(token)
 				delete(o.sheds, token)
-				o.mux.Unlock()
+			}
+			o.mux.Unlock()
+
+			if ok {
+				o.scheduleOverlapCheck()
 			}
 		}
 	}
 }
 
-func (o *Runner) setShedulesForWeb() {
+// shedulesForWeb return report of runned shedules for statistic server.
+func (o *Runner) shedulesForWeb() []string {
 	var result []string
 
-	o.mux.Lock()
-	result = append(result, fmt.Sprintln("Cron;Comment;Matchers;NextTimeRun"))
+	o.mux.RLock()
+	defer o.mux.RUnlock()
+
+	result = append(result, fmt.Sprintln("ID;Cron;Comment;Matchers;NextTimeRun"))
 
 	for _, shedInst := range o.sheds {
-		result = append(result, shedInst.GetSectionForWeb()...)
+		result = append(result, shedInst.GetSectionForWeb(&o.env)...)
 	}
-	o.mux.Unlock()
-	o.stat.SetShedules(result)
+
+	return result
 }
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/Volkov-Stanislav/cron"
+	"github.com/Volkov-Stanislav/silences-sheduler/clock"
+)
+
+// idleWait wait of scheduler gorutine without entries to activate.
+const idleWait = 24 * time.Hour
+
+// Scheduler single scheduling engine of shedules of all sections: entries are kept in heap by next activation,
+// one gorutine with one timer activates them. Every entry has own time zone.
+// Entries may be added and removed at any time, activated jobs run in own gorutines.
+type Scheduler struct {
+	clock   clock.Clock
+	mux     sync.Mutex
+	entries map[cron.EntryID]*entry
+	queue   entryQueue
+	lastID  cron.EntryID
+	wake    chan struct{} // Signal of change of first entry of queue.
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// entry of scheduler.
+type entry struct {
+	id       cron.EntryID
+	schedule cron.Schedule
+	loc      *time.Location
+	job      func()
+	next     time.Time // Zero for entry without next activation.
+	index    int       // Index in queue, -1 for entry out of queue.
+}
+
+// NewScheduler return scheduler activating jobs by time of clk, jobs are not activated before Start.
+func NewScheduler(clk clock.Clock) *Scheduler {
+	return &Scheduler{
+		clock:   clk,
+		entries: make(map[cron.EntryID]*entry),
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start activation of jobs.
+func (o *Scheduler) Start() {
+	go o.run()
+}
+
+// Stop activation of jobs, running jobs are not interrupted.
+func (o *Scheduler) Stop() {
+	close(o.stop)
+	<-o.done
+}
+
+// Add entry activating job by schedule in time zone loc, return ID of entry.
+func (o *Scheduler) Add(schedule cron.Schedule, loc *time.Location, job func()) cron.EntryID {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	o.lastID++
+	e := &entry{id: o.lastID, schedule: schedule, loc: loc, job: job, index: -1}
+	e.next = schedule.Next(o.clock.Now().In(loc))
+	o.entries[e.id] = e
+
+	if !e.next.IsZero() {
+		heap.Push(&o.queue, e)
+
+		if e.index == 0 {
+			o.notify()
+		}
+	}
+
+	return e.id
+}
+
+// Remove entry, running job is not interrupted.
+func (o *Scheduler) Remove(id cron.EntryID) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	e, ok := o.entries[id]
+	if !ok {
+		return
+	}
+
+	delete(o.entries, id)
+
+	if e.index >= 0 {
+		heap.Remove(&o.queue, e.index)
+	}
+}
+
+// Next return next activation of entry, zero time for removed entry or entry without next activation.
+func (o *Scheduler) Next(id cron.EntryID) time.Time {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	if e, ok := o.entries[id]; ok {
+		return e.next
+	}
+
+	return time.Time{}
+}
+
+// Len return count of entries.
+func (o *Scheduler) Len() int {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	return len(o.entries)
+}
+
+// notify run gorutine about change of first entry, must be called under lock.
+func (o *Scheduler) notify() {
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (o *Scheduler) run() {
+	defer close(o.done)
+
+	for {
+		timer := o.clock.NewTimer(o.wait(o.clock.Now()))
+
+		select {
+		case now := <-timer.C():
+			o.activate(now)
+		case <-o.wake:
+			timer.Stop()
+		case <-o.stop:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// wait return duration till first activation.
+func (o *Scheduler) wait(now time.Time) time.Duration {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	if len(o.queue) == 0 {
+		return idleWait
+	}
+
+	return o.queue[0].next.Sub(now)
+}
+
+// activate jobs of entries with activation not after now and shedule their next activation.
+func (o *Scheduler) activate(now time.Time) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	for len(o.queue) > 0 && !o.queue[0].next.After(now) {
+		e := o.queue[0]
+
+		go e.job()
+
+		e.next = e.schedule.Next(now.In(e.loc))
+		if e.next.IsZero() {
+			heap.Pop(&o.queue)
+		} else {
+			heap.Fix(&o.queue, 0)
+		}
+	}
+}
+
+// entryQueue heap of entries by next activation.
+type entryQueue []*entry
+
+func (o entryQueue) Len() int { return len(o) }
+
+func (o entryQueue) Less(i, j int) bool { return o[i].next.Before(o[j].next) }
+
+func (o entryQueue) Swap(i, j int) {
+	o[i], o[j] = o[j], o[i]
+	o[i].index = i
+	o[j].index = j
+}
+
+func (o *entryQueue) Push(x interface{}) {
+	e := x.(*entry)
+	e.index = len(*o)
+	*o = append(*o, e)
+}
+
+func (o *entryQueue) Pop() interface{} {
+	old := *o
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	e.index = -1
+	*o = old[:len(old)-1]
+
+	return e
+}
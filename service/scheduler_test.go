@@ -0,0 +1,198 @@
+package service_test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/cron"
+	"github.com/Volkov-Stanislav/silences-sheduler/clock"
+	"github.com/Volkov-Stanislav/silences-sheduler/service"
+)
+
+var parser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// everySchedule activates every interval.
+type everySchedule time.Duration
+
+func (o everySchedule) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(o))
+}
+
+func TestScheduler_Zones(t *testing.T) {
+	sched := service.NewScheduler(clock.System())
+
+	schedule, err := parser.Parse("0 0 3 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, offset := range []int{-5, 0, 3, 10} {
+		loc := time.FixedZone(fmt.Sprint(offset), offset*60*60)
+		next := sched.Next(sched.Add(schedule, loc, func() {}))
+
+		if local := next.In(loc); local.Hour() != 3 || local.Minute() != 0 || !next.After(time.Now()) {
+			t.Errorf("Next() in %v = %v, want next 03:00", loc, local)
+		}
+	}
+}
+
+func TestScheduler_AddRemove(t *testing.T) {
+	sched := service.NewScheduler(clock.System())
+	sched.Start()
+
+	defer sched.Stop()
+
+	var fast, slow, removed int32
+
+	sched.Add(everySchedule(20*time.Millisecond), time.UTC, func() { atomic.AddInt32(&fast, 1) })
+	sched.Add(everySchedule(time.Hour), time.UTC, func() { atomic.AddInt32(&slow, 1) })
+	id := sched.Add(everySchedule(20*time.Millisecond), time.UTC, func() { atomic.AddInt32(&removed, 1) })
+
+	sched.Remove(id)
+
+	if sched.Len() != 2 || !sched.Next(id).IsZero() {
+		t.Fatalf("Len() = %v, Next() of removed entry = %v", sched.Len(), sched.Next(id))
+	}
+
+	time.Sleep(300 * time.Millisecond)
+
+	if got := atomic.LoadInt32(&fast); got < 5 {
+		t.Errorf("fast entry activated %v times, want at least 5", got)
+	}
+
+	if got := atomic.LoadInt32(&slow); got != 0 {
+		t.Errorf("slow entry activated %v times", got)
+	}
+
+	if got := atomic.LoadInt32(&removed); got != 0 {
+		t.Errorf("removed entry activated %v times", got)
+	}
+}
+
+func TestScheduler_Once(t *testing.T) {
+	sched := service.NewScheduler(clock.System())
+	sched.Start()
+
+	defer sched.Stop()
+
+	activated := make(chan struct{}, 2)
+	start := time.Now().Add(50 * time.Millisecond)
+
+	sched.Add(onceAt(start), time.Local, func() { activated <- struct{}{} })
+
+	select {
+	case <-activated:
+	case <-time.After(5 * time.Second):
+		t.Fatal("entry not activated")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if len(activated) != 0 {
+		t.Error("entry activated twice")
+	}
+}
+
+// onceAt activates once at start.
+type onceAt time.Time
+
+func (o onceAt) Next(t time.Time) time.Time {
+	if time.Time(o).After(t) {
+		return time.Time(o)
+	}
+
+	return time.Time{}
+}
+
+// BenchmarkScheduler_Load add and remove entries of many sections: shared scheduler against cron instance per section.
+func BenchmarkScheduler_Load(b *testing.B) {
+	schedule, err := parser.Parse("0 0 3 * * *")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, count := range []int{1000, 10000, 50000} {
+		b.Run(fmt.Sprintf("shared-%v", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sched := service.NewScheduler(clock.System())
+				sched.Start()
+
+				ids := make([]cron.EntryID, count)
+				for j := range ids {
+					ids[j] = sched.Add(schedule, time.FixedZone("", (j%24-12)*60*60), func() {})
+				}
+
+				for _, id := range ids {
+					sched.Remove(id)
+				}
+
+				sched.Stop()
+			}
+		})
+
+		b.Run(fmt.Sprintf("cron-per-section-%v", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				crons := make([]*cron.Cron, count)
+				for j := range crons {
+					crons[j] = cron.New(cron.WithSeconds(), cron.WithLocation(time.FixedZone("", (j%24-12)*60*60)))
+					crons[j].Schedule(schedule, cron.FuncJob(func() {}))
+					crons[j].Start()
+				}
+
+				for _, c := range crons {
+					c.Stop()
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkScheduler_AddRemove add and remove one entry in scheduler with 50000 entries.
+func BenchmarkScheduler_AddRemove(b *testing.B) {
+	schedule, err := parser.Parse("0 0 3 * * *")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	sched := service.NewScheduler(clock.System())
+	sched.Start()
+
+	defer sched.Stop()
+
+	for j := 0; j < 50000; j++ {
+		sched.Add(schedule, time.FixedZone("", (j%24-12)*60*60), func() {})
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sched.Remove(sched.Add(schedule, time.UTC, func() {}))
+	}
+}
+
+// BenchmarkScheduler_Activate activate 10000 entries due at same time.
+func BenchmarkScheduler_Activate(b *testing.B) {
+	const count = 10000
+
+	for i := 0; i < b.N; i++ {
+		var activated int32
+
+		done := make(chan struct{})
+		start := time.Now().Add(10 * time.Millisecond)
+		sched := service.NewScheduler(clock.System())
+
+		for j := 0; j < count; j++ {
+			sched.Add(onceAt(start), time.UTC, func() {
+				if atomic.AddInt32(&activated, 1) == count {
+					close(done)
+				}
+			})
+		}
+
+		sched.Start()
+		<-done
+		sched.Stop()
+	}
+}
@@ -0,0 +1,166 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/service"
+	"github.com/Volkov-Stanislav/silences-sheduler/stats"
+	"go.uber.org/zap"
+)
+
+// blockingSink blocks creation of silence until release or cancel of request context.
+type blockingSink struct {
+	started chan struct{}
+	release chan struct{}
+	result  chan error
+}
+
+func (o *blockingSink) Create(ctx context.Context, w models.Window) (string, error) {
+	close(o.started)
+
+	select {
+	case <-o.release:
+		o.result <- nil
+		return "id", nil
+	case <-ctx.Done():
+		o.result <- ctx.Err()
+		return "", ctx.Err()
+	}
+}
+
+func (o *blockingSink) Expire(ctx context.Context, w models.Window, id string) error {
+	return nil
+}
+
+func TestRunner_Shutdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		release bool
+		wantErr bool
+	}{
+		{name: "In-flight request completed", release: true},
+		{name: "In-flight request cancelled after grace", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := zap.NewNop()
+			sink := &blockingSink{started: make(chan struct{}), release: make(chan struct{}), result: make(chan error, 1)}
+
+			runner, err := service.NewRunner(map[string]models.Sink{models.DefaultSink: sink}, nil, "", logger,
+				stats.NewInstance("0", logger), prom)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			runner.Start()
+
+			now := time.Now()
+			section := models.SheduleSection{Shedules: []models.Shedule{{
+				Start:   now.Add(-time.Minute).Format("2006-01-02T15:04"),
+				End:     now.Add(time.Hour).Format("2006-01-02T15:04"),
+				Silence: models.Silence{Matchers: []models.Matchers{{IsEqual: true, Name: "service", Value: "db"}}},
+			}}}
+			section.SetSectionName("once.yaml")
+			section.SetToken("once")
+
+			add, _ := runner.GetChannels()
+			add <- section
+
+			select {
+			case <-sink.started:
+			case <-time.After(5 * time.Second):
+				t.Fatal("one-off shedule not started")
+			}
+
+			if tt.release {
+				time.AfterFunc(50*time.Millisecond, func() { close(sink.release) })
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+
+			if err := runner.Shutdown(ctx); (err != nil) != tt.wantErr {
+				t.Fatalf("Shutdown() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			again := make(chan error, 1)
+			go func() { again <- runner.Shutdown(context.Background()) }()
+
+			select {
+			case err := <-again:
+				if (err != nil) != tt.wantErr {
+					t.Errorf("second Shutdown() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("second Shutdown() blocked")
+			}
+
+			select {
+			case err := <-sink.result:
+				if (err != nil) != tt.wantErr {
+					t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			case <-time.After(5 * time.Second):
+				t.Error("in-flight request not finished after shutdown")
+			}
+		})
+	}
+}
+
+func TestRunner_ShutdownWaitRunNow(t *testing.T) {
+	logger := zap.NewNop()
+	sink := &blockingSink{started: make(chan struct{}), release: make(chan struct{}), result: make(chan error, 1)}
+
+	runner, err := service.NewRunner(map[string]models.Sink{models.DefaultSink: sink}, nil, "", logger,
+		stats.NewInstance("0", logger), prom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner.Start()
+
+	section := virtualSection("night", "UTC", models.Shedule{Cron: "0 0 3 1 1 *", Duration: models.Duration(time.Hour)})
+	load(runner, nil, section)
+
+	id := runner.Preview("night.yaml", time.Now(), time.Now().AddDate(1, 0, 1), 1)[0].ID
+	ran := make(chan error, 1)
+
+	go func() { ran <- runner.RunNow(id, "ops") }()
+
+	select {
+	case <-sink.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("run-now not started")
+	}
+
+	time.AfterFunc(50*time.Millisecond, func() { close(sink.release) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := runner.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	select {
+	case err := <-sink.result:
+		if err != nil {
+			t.Errorf("Create() error = %v, want completed run-now", err)
+		}
+	default:
+		t.Error("Shutdown() returned before run-now completed")
+	}
+
+	if err := <-ran; err != nil {
+		t.Errorf("RunNow() error = %v", err)
+	}
+
+	if err := runner.RunNow(id, "ops"); !errors.Is(err, service.ErrSheduleNotFound) {
+		t.Errorf("RunNow() after Shutdown error = %v, want %v", err, service.ErrSheduleNotFound)
+	}
+}
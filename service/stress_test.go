@@ -0,0 +1,133 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/clock"
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/service"
+	"github.com/Volkov-Stanislav/silences-sheduler/stats"
+	"go.uber.org/zap"
+)
+
+// TestRunner_Stress fire hundreds of shedules every virtual second, while sections are reloaded and reports are requested.
+func TestRunner_Stress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("stress test")
+	}
+
+	const count = 300
+
+	logger := zap.NewNop()
+	sink := &fakeSink{}
+	stat := stats.NewInstance("0", logger)
+
+	fake := clock.NewFake(time.Date(2026, 11, 3, 1, 0, 0, 0, time.UTC))
+
+	runner, err := service.NewRunner(map[string]models.Sink{models.DefaultSink: sink}, nil, "", logger, stat, prom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner.SetClock(fake)
+	runner.Start()
+
+	section := func(i int) models.SheduleSection {
+		section := models.SheduleSection{Shedules: []models.Shedule{{
+			Cron:     "* * * * * *",
+			Duration: models.Duration(time.Minute),
+			Silence:  models.Silence{Matchers: []models.Matchers{{IsEqual: true, Name: "host", Value: fmt.Sprint(i)}}},
+		}}}
+		section.SetSectionName(fmt.Sprintf("host%v.yaml", i))
+		section.SetToken(fmt.Sprint(i))
+
+		return section
+	}
+
+	add, del := runner.GetChannels()
+
+	var (
+		wg     sync.WaitGroup
+		loaded sync.WaitGroup
+		done   = make(chan struct{})
+	)
+
+	loaded.Add(1)
+
+	go func() {
+		defer loaded.Done()
+
+		for i := 0; i < count; i++ {
+			add <- section(i)
+		}
+
+		// reload of every tenth section.
+		for i := 0; i < count; i += 10 {
+			del <- fmt.Sprint(i)
+			add <- section(i)
+		}
+
+		// runner receive next message only after all previous are handled.
+		del <- ""
+	}()
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				stat.ServeShedules(httptest.NewRecorder(), httptest.NewRequest("GET", "/shedules", nil))
+				stat.ServeStats(httptest.NewRecorder(), httptest.NewRequest("GET", "/stats", nil))
+				runner.ServeCalendar(httptest.NewRecorder(), httptest.NewRequest("GET", "/shedules.ics?section=host1.yaml", nil))
+				runner.Preview("host2.yaml", fake.Now(), fake.Now().Add(time.Minute), 10)
+			}
+		}()
+	}
+
+	// every shedule fires on every virtual second, while reports are requested.
+	loaded.Wait()
+
+	for i := 0; i < 3; i++ {
+		fake.BlockUntil(1)
+		fake.Advance(time.Second)
+	}
+
+	hosts := make(map[string]bool)
+
+	for deadline := time.Now().Add(30 * time.Second); len(hosts) < count && time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		for _, window := range sink.created() {
+			hosts[window.Silence.Matchers[0].Value] = true
+		}
+	}
+
+	close(done)
+	wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := runner.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	if len(hosts) != count {
+		t.Errorf("silences created for %v hosts, want %v", len(hosts), count)
+	}
+
+	if got := len(stat.Stats()); got == 0 {
+		t.Error("no runs in stats")
+	}
+}
@@ -0,0 +1,43 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+func TestRunner_MissingOptionalTarget(t *testing.T) {
+	tests := []struct {
+		name   string
+		target func(section *models.SheduleSection)
+	}{
+		{
+			name: "PagerDuty",
+			target: func(section *models.SheduleSection) {
+				section.PagerDuty = &models.PagerDutyTarget{Services: []string{"PSERVICE"}}
+			},
+		},
+		{
+			name:   "Zabbix of CSV section",
+			target: func(section *models.SheduleSection) { section.Zabbix = &models.ZabbixTarget{} },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fire := time.Date(2026, 11, 3, 1, 0, 0, 0, time.UTC)
+			runner, fake, sink := virtualRunner(t, fire.Add(-time.Minute))
+
+			section := virtualSection("maintenance", "UTC", models.Shedule{Cron: "0 0 1 * * *", Duration: models.Duration(time.Hour)})
+			tt.target(&section)
+			load(runner, nil, section)
+
+			fake.Advance(time.Minute)
+
+			if windows := waitCreated(t, sink, 1); windows[0].Section.GetSectionName() != "maintenance.yaml" {
+				t.Errorf("silence created for section %v", windows[0].Section.GetSectionName())
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package service_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/service"
+	"github.com/Volkov-Stanislav/silences-sheduler/sinks"
+	"github.com/Volkov-Stanislav/silences-sheduler/stats"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// silencesSetted return value of silences counter of tenant and sink.
+func silencesSetted(t *testing.T, tenant, sink string) float64 {
+	t.Helper()
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, family := range families {
+		if family.GetName() != "silences_sheduler_silences_setted" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+
+			if labels["tenant"] == tenant && labels["sink"] == sink {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+
+	return 0
+}
+
+func TestRunner_Tenants(t *testing.T) {
+	var (
+		mux     sync.Mutex
+		tenants []string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.Lock()
+		tenants = append(tenants, r.Header.Get("X-Scope-OrgID"))
+		mux.Unlock()
+
+		_, _ = w.Write([]byte(`{"silenceID":"id-1"}`))
+	}))
+	defer srv.Close()
+
+	logger := zap.NewNop()
+	sink := sinks.NewAlertmanager(srv.URL + "/api/v2/silences")
+
+	runner, err := service.NewRunner(map[string]models.Sink{models.DefaultSink: sink}, nil, "default-tenant", logger,
+		stats.NewInstance("0", logger), prom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner.Start()
+	defer runner.Stop()
+
+	own := virtualSection("own", "UTC", models.Shedule{Cron: "0 0 1 * * *", Duration: models.Duration(time.Hour)})
+	own.Tenant = "team-a"
+	fallback := virtualSection("fallback", "UTC", models.Shedule{Cron: "0 0 2 * * *", Duration: models.Duration(time.Hour)})
+
+	before := map[string]float64{
+		"team-a":         silencesSetted(t, "team-a", models.DefaultSink),
+		"default-tenant": silencesSetted(t, "default-tenant", models.DefaultSink),
+	}
+
+	load(runner, nil, own, fallback)
+
+	for _, name := range []string{"own.yaml", "fallback.yaml"} {
+		occurrences := runner.Preview(name, time.Now(), time.Now().AddDate(0, 0, 2), 1)
+		if len(occurrences) != 1 {
+			t.Fatalf("Preview(%v) = %v", name, occurrences)
+		}
+
+		if err := runner.RunNow(occurrences[0].ID, "tester"); err != nil {
+			t.Fatalf("RunNow(%v) error = %v", name, err)
+		}
+	}
+
+	mux.Lock()
+	got := append([]string{}, tenants...)
+	mux.Unlock()
+
+	if len(got) != 2 || got[0] != "team-a" || got[1] != "default-tenant" {
+		t.Errorf("X-Scope-OrgID of requests = %q, want [team-a default-tenant]", got)
+	}
+
+	for tenant, value := range before {
+		if after := silencesSetted(t, tenant, models.DefaultSink); after != value+1 {
+			t.Errorf("silences of tenant %v = %v, want %v", tenant, after, value+1)
+		}
+	}
+}
+
+func TestRunner_DefaultTenantNotGrafanaOrg(t *testing.T) {
+	logger := zap.NewNop()
+	grafana := &fakeSink{}
+
+	runner, err := service.NewRunner(map[string]models.Sink{"grafana": grafana}, nil, "default-tenant", logger,
+		stats.NewInstance("0", logger), prom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner.Start()
+	defer runner.Stop()
+
+	section := virtualSection("grafana", "UTC", models.Shedule{Cron: "0 0 1 * * *", Duration: models.Duration(time.Hour)})
+	section.Sink = "grafana"
+	load(runner, nil, section)
+
+	occurrences := runner.Preview("grafana.yaml", time.Now(), time.Now().AddDate(0, 0, 2), 1)
+	if len(occurrences) != 1 {
+		t.Fatalf("Preview() = %v", occurrences)
+	}
+
+	if err := runner.RunNow(occurrences[0].ID, "tester"); err != nil {
+		t.Fatalf("RunNow() error = %v", err)
+	}
+
+	if windows := grafana.created(); len(windows) != 1 || windows[0].Tenant != "" {
+		t.Errorf("Grafana windows = %v, want one window without tenant", windows)
+	}
+}
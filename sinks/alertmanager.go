@@ -0,0 +1,60 @@
+package sinks
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+// Alertmanager sink creating silences through Alertmanager API v2.
+type Alertmanager struct {
+	apiURL string // URL of silences endpoint, .../api/v2/silences
+	client *http.Client
+}
+
+// NewAlertmanager return Alertmanager sink for silences API URL.
+func NewAlertmanager(apiURL string) *Alertmanager {
+	return &Alertmanager{
+		apiURL: apiURL,
+		client: http.DefaultClient,
+	}
+}
+
+// Create silence, tenant of window sent as X-Scope-OrgID header.
+func (o *Alertmanager) Create(ctx context.Context, w models.Window) (string, error) {
+	var result models.SilenceID
+
+	err := doJSON(ctx, o.client, http.MethodPost, o.apiURL, o.header(w.Tenant), w.Silence, &result)
+
+	return result.SilenceID, err
+}
+
+// Extend silence with ID up to end of window.
+func (o *Alertmanager) Extend(ctx context.Context, w models.Window, id string) (string, error) {
+	w.Silence.ID = id
+
+	return o.Create(ctx, w)
+}
+
+// Expire silence with ID.
+func (o *Alertmanager) Expire(ctx context.Context, w models.Window, id string) error {
+	return doJSON(ctx, o.client, http.MethodDelete, silenceURL(o.apiURL, id), o.header(w.Tenant), nil, nil)
+}
+
+func (o *Alertmanager) header(tenant string) http.Header {
+	header := http.Header{}
+
+	if tenant != "" {
+		header.Set("X-Scope-OrgID", tenant)
+	}
+
+	return header
+}
+
+// silenceURL return URL of one silence from URL of silences endpoint.
+func silenceURL(apiURL, id string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(apiURL, "/"), "s") + "/" + url.PathEscape(id)
+}
@@ -0,0 +1,98 @@
+package sinks_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/sinks"
+)
+
+func TestAlertmanager_CreateExpire(t *testing.T) {
+	var (
+		created models.Silence
+		tenant  string
+		deleted string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant = r.Header.Get("X-Scope-OrgID")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/silences":
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Errorf("decode silence: %v", err)
+			}
+
+			_, _ = w.Write([]byte(`{"silenceID":"id-1"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v2/silence/id-1":
+			deleted = "id-1"
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	sink := sinks.NewAlertmanager(srv.URL + "/api/v2/silences")
+	window := models.Window{
+		Tenant:  "team-a",
+		Silence: models.Silence{Comment: "Backups"},
+	}
+
+	id, err := sink.Create(context.Background(), window)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if id != "id-1" || created.Comment != "Backups" || tenant != "team-a" {
+		t.Errorf("Create() id = %v, comment = %v, tenant = %v", id, created.Comment, tenant)
+	}
+
+	if id, err = sink.Extend(context.Background(), window, id); err != nil || created.ID != "id-1" {
+		t.Fatalf("Extend() error = %v, sent id = %v", err, created.ID)
+	}
+
+	if err := sink.Expire(context.Background(), window, id); err != nil {
+		t.Fatalf("Expire() error = %v", err)
+	}
+
+	if deleted != "id-1" {
+		t.Errorf("Expire() deleted = %v, want id-1", deleted)
+	}
+}
+
+func TestAlertmanager_CreateError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad matchers", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	sink := sinks.NewAlertmanager(srv.URL + "/api/v2/silences")
+
+	if _, err := sink.Create(context.Background(), models.Window{}); err == nil {
+		t.Error("Create() error = nil, want error on status 400")
+	}
+}
+
+func TestAlertmanager_NoTenant(t *testing.T) {
+	header := http.Header{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		_, _ = w.Write([]byte(`{"silenceID":"id-1"}`))
+	}))
+	defer srv.Close()
+
+	sink := sinks.NewAlertmanager(srv.URL + "/api/v2/silences")
+
+	if _, err := sink.Create(context.Background(), models.Window{}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if _, ok := header["X-Scope-Orgid"]; ok {
+		t.Errorf("X-Scope-OrgID sent for single tenant Alertmanager: %q", header.Get("X-Scope-OrgID"))
+	}
+}
@@ -0,0 +1,68 @@
+package sinks
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+// grafanaSilencesPath path of silences endpoint of Grafana Alertmanager-compatible API.
+const grafanaSilencesPath = "/api/alertmanager/grafana/api/v2/silences"
+
+// Grafana sink creating silences of Grafana-managed alerts.
+type Grafana struct {
+	apiURL string // URL of Grafana silences endpoint.
+	token  string // Service account token.
+	client *http.Client
+}
+
+// NewGrafana return Grafana sink for Grafana base URL, service account token read from tokenFile.
+func NewGrafana(grafanaURL, tokenFile string) (*Grafana, error) {
+	token, err := readToken(tokenFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Grafana{
+		apiURL: strings.TrimSuffix(grafanaURL, "/") + grafanaSilencesPath,
+		token:  token,
+		client: http.DefaultClient,
+	}, nil
+}
+
+// Create silence, tenant of window used as Grafana organization ID.
+func (o *Grafana) Create(ctx context.Context, w models.Window) (string, error) {
+	var result models.SilenceID
+
+	err := doJSON(ctx, o.client, http.MethodPost, o.apiURL, o.header(w.Tenant), w.Silence, &result)
+
+	return result.SilenceID, err
+}
+
+// Extend silence with ID up to end of window.
+func (o *Grafana) Extend(ctx context.Context, w models.Window, id string) (string, error) {
+	w.Silence.ID = id
+
+	return o.Create(ctx, w)
+}
+
+// Expire silence with ID.
+func (o *Grafana) Expire(ctx context.Context, w models.Window, id string) error {
+	return doJSON(ctx, o.client, http.MethodDelete, silenceURL(o.apiURL, id), o.header(w.Tenant), nil, nil)
+}
+
+func (o *Grafana) header(tenant string) http.Header {
+	header := http.Header{}
+
+	if o.token != "" {
+		header.Set("Authorization", "Bearer "+o.token)
+	}
+
+	if tenant != "" {
+		header.Set("X-Grafana-Org-Id", tenant)
+	}
+
+	return header
+}
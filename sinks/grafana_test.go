@@ -0,0 +1,66 @@
+package sinks_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/sinks"
+)
+
+func TestGrafana_CreateExpire(t *testing.T) {
+	var calls []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer glsa_token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+r.Header.Get("X-Grafana-Org-Id"))
+
+		if r.Method == http.MethodPost {
+			_, _ = w.Write([]byte(`{"silenceID":"g-1"}`))
+		}
+	}))
+	defer srv.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("glsa_token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	sink, err := sinks.NewGrafana(srv.URL+"/", tokenFile)
+	if err != nil {
+		t.Fatalf("NewGrafana() error = %v", err)
+	}
+
+	window := models.Window{Tenant: "2"}
+
+	id, err := sink.Create(context.Background(), window)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if err := sink.Expire(context.Background(), window, id); err != nil {
+		t.Fatalf("Expire() error = %v", err)
+	}
+
+	want := []string{
+		"POST /api/alertmanager/grafana/api/v2/silences 2",
+		"DELETE /api/alertmanager/grafana/api/v2/silence/g-1 2",
+	}
+	if len(calls) != len(want) || calls[0] != want[0] || calls[1] != want[1] {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestNewGrafana_NoTokenFile(t *testing.T) {
+	if _, err := sinks.NewGrafana("http://localhost:3000", filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("NewGrafana() error = nil, want error for missing token file")
+	}
+}
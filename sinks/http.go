@@ -0,0 +1,79 @@
+// Package sinks implements targets where shedules create and expire silences.
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// doJSON send request with JSON body to url and decode JSON reply into result.
+// body and result may be nil.
+func doJSON(ctx context.Context, client *http.Client, method, url string, header http.Header, body, result interface{}) error {
+	var reader io.Reader
+
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		reader = bytes.NewReader(b)
+	}
+
+	r, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+
+	for key, values := range header {
+		for _, value := range values {
+			r.Header.Add(key, value)
+		}
+	}
+
+	if body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := client.Do(r)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("%v %v: status %v: %s", method, url, res.Status, strings.TrimSpace(string(data)))
+	}
+
+	if result == nil || len(data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(data, result)
+}
+
+// readToken read API token from file.
+func readToken(fileName string) (string, error) {
+	if fileName == "" {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return "", fmt.Errorf("read token file: %w", err)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,120 @@
+package sinks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+// PagerDutyAPIURL default URL of PagerDuty REST API.
+const PagerDutyAPIURL = "https://api.pagerduty.com"
+
+// PagerDuty target creating maintenance windows for PagerDuty services of section.
+type PagerDuty struct {
+	apiURL string // URL of PagerDuty REST API.
+	token  string // REST API token.
+	from   string // Email of PagerDuty user, required for account level tokens.
+	client *http.Client
+}
+
+type pagerDutyReference struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+type pagerDutyWindow struct {
+	ID          string               `json:"id,omitempty"`
+	Type        string               `json:"type"`
+	StartTime   time.Time            `json:"start_time"`
+	EndTime     time.Time            `json:"end_time"`
+	Description string               `json:"description"`
+	Services    []pagerDutyReference `json:"services"`
+}
+
+type pagerDutyWindowBody struct {
+	MaintenanceWindow pagerDutyWindow `json:"maintenance_window"`
+}
+
+// NewPagerDuty return PagerDuty target for API URL, API token read from tokenFile.
+func NewPagerDuty(apiURL, tokenFile, from string) (*PagerDuty, error) {
+	token, err := readToken(tokenFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiURL == "" {
+		apiURL = PagerDutyAPIURL
+	}
+
+	return &PagerDuty{
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+		token:  token,
+		from:   from,
+		client: http.DefaultClient,
+	}, nil
+}
+
+// Create maintenance window for services of window section.
+func (o *PagerDuty) Create(ctx context.Context, w models.Window) (string, error) {
+	if w.Section == nil || w.Section.PagerDuty == nil || len(w.Section.PagerDuty.Services) == 0 {
+		return "", fmt.Errorf("no PagerDuty services in section")
+	}
+
+	body := pagerDutyWindowBody{
+		MaintenanceWindow: pagerDutyWindow{
+			Type:        "maintenance_window",
+			StartTime:   w.Start.UTC(),
+			EndTime:     w.End.UTC(),
+			Description: w.Silence.Comment,
+		},
+	}
+
+	for _, service := range w.Section.PagerDuty.Services {
+		body.MaintenanceWindow.Services = append(body.MaintenanceWindow.Services,
+			pagerDutyReference{ID: service, Type: "service_reference"})
+	}
+
+	var result pagerDutyWindowBody
+
+	err := doJSON(ctx, o.client, http.MethodPost, o.apiURL+"/maintenance_windows", o.header(), body, &result)
+
+	return result.MaintenanceWindow.ID, err
+}
+
+// Extend maintenance window with ID up to end of window.
+func (o *PagerDuty) Extend(ctx context.Context, w models.Window, id string) (string, error) {
+	body := map[string]interface{}{
+		"maintenance_window": map[string]interface{}{
+			"type":     "maintenance_window",
+			"end_time": w.End.UTC(),
+		},
+	}
+
+	var result pagerDutyWindowBody
+
+	err := doJSON(ctx, o.client, http.MethodPut, o.apiURL+"/maintenance_windows/"+url.PathEscape(id), o.header(), body, &result)
+
+	return result.MaintenanceWindow.ID, err
+}
+
+// Expire delete maintenance window with ID.
+func (o *PagerDuty) Expire(ctx context.Context, w models.Window, id string) error {
+	return doJSON(ctx, o.client, http.MethodDelete, o.apiURL+"/maintenance_windows/"+url.PathEscape(id), o.header(), nil, nil)
+}
+
+func (o *PagerDuty) header() http.Header {
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.pagerduty+json;version=2")
+	header.Set("Authorization", "Token token="+o.token)
+
+	if o.from != "" {
+		header.Set("From", o.from)
+	}
+
+	return header
+}
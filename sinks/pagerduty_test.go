@@ -0,0 +1,106 @@
+package sinks_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/sinks"
+)
+
+// fakePagerDuty is local fake of PagerDuty maintenance windows REST API.
+type fakePagerDuty struct {
+	windows map[string]map[string]interface{}
+}
+
+func (o *fakePagerDuty) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Token token=pd_token" || r.Header.Get("From") != "bot@example.com" {
+		http.Error(w, `{"error":{"message":"unauthorized"}}`, http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/maintenance_windows":
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		window := body["maintenance_window"]
+		window["id"] = "PW1"
+		o.windows["PW1"] = window
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"maintenance_window": window})
+	case r.Method == http.MethodDelete && r.URL.Path == "/maintenance_windows/PW1":
+		delete(o.windows, "PW1")
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, `{"error":{"message":"not found"}}`, http.StatusNotFound)
+	}
+}
+
+func TestPagerDuty_CreateExpire(t *testing.T) {
+	fake := &fakePagerDuty{windows: map[string]map[string]interface{}{}}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("pd_token"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	target, err := sinks.NewPagerDuty(srv.URL, tokenFile, "bot@example.com")
+	if err != nil {
+		t.Fatalf("NewPagerDuty() error = %v", err)
+	}
+
+	start := time.Date(2026, 11, 3, 19, 0, 0, 0, time.UTC)
+	window := models.Window{
+		Section: &models.SheduleSection{PagerDuty: &models.PagerDutyTarget{Services: []string{"PSVC1", "PSVC2"}}},
+		Silence: models.Silence{Comment: "Backups"},
+		Start:   start,
+		End:     start.Add(40 * time.Minute),
+	}
+
+	id, err := target.Create(context.Background(), window)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	created := fake.windows[id]
+	if created == nil {
+		t.Fatalf("Create() id = %v, window not stored", id)
+	}
+
+	if created["start_time"] != "2026-11-03T19:00:00Z" || created["end_time"] != "2026-11-03T19:40:00Z" ||
+		created["description"] != "Backups" || len(created["services"].([]interface{})) != 2 {
+		t.Errorf("Create() window = %v", created)
+	}
+
+	if err := target.Expire(context.Background(), window, id); err != nil {
+		t.Fatalf("Expire() error = %v", err)
+	}
+
+	if len(fake.windows) != 0 {
+		t.Errorf("Expire() windows left = %v", fake.windows)
+	}
+}
+
+func TestPagerDuty_CreateNoServices(t *testing.T) {
+	target, err := sinks.NewPagerDuty("http://127.0.0.1:1", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := target.Create(context.Background(), models.Window{Section: &models.SheduleSection{}}); err == nil {
+		t.Error("Create() error = nil, want error for section without services")
+	}
+}
@@ -0,0 +1,206 @@
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+)
+
+// zabbixNameLength max length of Zabbix maintenance name.
+const zabbixNameLength = 128
+
+// Zabbix target creating Zabbix maintenance periods through JSON-RPC API (Zabbix 6.4+).
+type Zabbix struct {
+	apiURL string // URL of JSON-RPC endpoint, .../api_jsonrpc.php
+	token  string // API token.
+	client *http.Client
+	id     int64 // Last JSON-RPC request ID.
+}
+
+type zabbixRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+	ID      int64       `json:"id"`
+}
+
+type zabbixError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+type zabbixResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *zabbixError    `json:"error"`
+}
+
+type zabbixMaintenance struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	ActiveSince int64               `json:"active_since"`
+	ActiveTill  int64               `json:"active_till"`
+	Groups      []map[string]string `json:"groups,omitempty"`
+	Hosts       []map[string]string `json:"hosts,omitempty"`
+	Timeperiods []zabbixTimeperiod  `json:"timeperiods"`
+}
+
+type zabbixTimeperiod struct {
+	TimeperiodType int   `json:"timeperiod_type"`
+	StartDate      int64 `json:"start_date"`
+	Period         int64 `json:"period"`
+}
+
+// NewZabbix return Zabbix target for JSON-RPC URL, API token read from tokenFile.
+func NewZabbix(apiURL, tokenFile string) (*Zabbix, error) {
+	token, err := readToken(tokenFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Zabbix{
+		apiURL: apiURL,
+		token:  token,
+		client: http.DefaultClient,
+	}, nil
+}
+
+// Create maintenance period for host groups and hosts of window section and shedule.
+func (o *Zabbix) Create(ctx context.Context, w models.Window) (string, error) {
+	var groups, hosts []string
+
+	if w.Section != nil && w.Section.Zabbix != nil {
+		groups = append(groups, w.Section.Zabbix.Groups...)
+		hosts = append(hosts, w.Section.Zabbix.Hosts...)
+	}
+
+	if w.Shedule != nil {
+		hosts = append(hosts, w.Shedule.Hosts...)
+	}
+
+	maintenance := zabbixMaintenance{
+		Name:        zabbixName(w),
+		Description: w.Silence.Comment,
+		ActiveSince: w.Start.Unix(),
+		ActiveTill:  w.End.Unix(),
+		Timeperiods: []zabbixTimeperiod{
+			{TimeperiodType: 0, StartDate: w.Start.Unix(), Period: int64(w.End.Sub(w.Start).Seconds())},
+		},
+	}
+
+	groupIDs, err := o.ids(ctx, "hostgroup.get", "name", "groupid", groups)
+	if err != nil {
+		return "", err
+	}
+
+	for _, id := range groupIDs {
+		maintenance.Groups = append(maintenance.Groups, map[string]string{"groupid": id})
+	}
+
+	hostIDs, err := o.ids(ctx, "host.get", "host", "hostid", hosts)
+	if err != nil {
+		return "", err
+	}
+
+	for _, id := range hostIDs {
+		maintenance.Hosts = append(maintenance.Hosts, map[string]string{"hostid": id})
+	}
+
+	if len(maintenance.Groups) == 0 && len(maintenance.Hosts) == 0 {
+		return "", fmt.Errorf("no Zabbix host groups or hosts found for %v %v", groups, hosts)
+	}
+
+	var result struct {
+		MaintenanceIDs []string `json:"maintenanceids"`
+	}
+
+	if err := o.call(ctx, "maintenance.create", maintenance, &result); err != nil {
+		return "", err
+	}
+
+	if len(result.MaintenanceIDs) == 0 {
+		return "", fmt.Errorf("maintenance.create returned no maintenance ID")
+	}
+
+	return result.MaintenanceIDs[0], nil
+}
+
+// Expire delete maintenance period with ID.
+func (o *Zabbix) Expire(ctx context.Context, w models.Window, id string) error {
+	return o.call(ctx, "maintenance.delete", []string{id}, nil)
+}
+
+// ids return IDs (field idField) of objects with names in filter field nameField.
+func (o *Zabbix) ids(ctx context.Context, method, nameField, idField string, names []string) ([]string, error) {
+	var (
+		result  []map[string]string
+		objects []string
+	)
+
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	params := map[string]interface{}{
+		"output": []string{idField},
+		"filter": map[string][]string{nameField: names},
+	}
+
+	if err := o.call(ctx, method, params, &result); err != nil {
+		return nil, err
+	}
+
+	for _, obj := range result {
+		objects = append(objects, obj[idField])
+	}
+
+	return objects, nil
+}
+
+// call JSON-RPC method and decode result.
+func (o *Zabbix) call(ctx context.Context, method string, params, result interface{}) error {
+	var res zabbixResponse
+
+	header := http.Header{}
+	if o.token != "" {
+		header.Set("Authorization", "Bearer "+o.token)
+	}
+
+	req := zabbixRequest{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+		ID:      atomic.AddInt64(&o.id, 1),
+	}
+
+	if err := doJSON(ctx, o.client, http.MethodPost, o.apiURL, header, req, &res); err != nil {
+		return err
+	}
+
+	if res.Error != nil {
+		return fmt.Errorf("%v: %v %v (code %v)", method, res.Error.Message, res.Error.Data, res.Error.Code)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(res.Result, result)
+}
+
+// zabbixName return unique name of maintenance for window.
+func zabbixName(w models.Window) string {
+	name := strings.TrimSpace(w.Silence.Comment + " " + w.Start.UTC().Format("2006-01-02T15:04:05Z"))
+
+	runes := []rune(name)
+	if len(runes) > zabbixNameLength {
+		name = string(runes[len(runes)-zabbixNameLength:])
+	}
+
+	return name
+}
@@ -0,0 +1,127 @@
+package sinks_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/sinks"
+)
+
+// fakeZabbix is local fake of Zabbix JSON-RPC API.
+type fakeZabbix struct {
+	maintenances map[string]map[string]interface{}
+}
+
+func (o *fakeZabbix) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+		ID     int64           `json:"id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	reply := func(result interface{}) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "result": result, "id": req.ID})
+	}
+
+	switch req.Method {
+	case "hostgroup.get":
+		reply([]map[string]string{{"groupid": "11"}})
+	case "host.get":
+		reply([]map[string]string{{"hostid": "101"}, {"hostid": "102"}})
+	case "maintenance.create":
+		var params map[string]interface{}
+		_ = json.Unmarshal(req.Params, &params)
+		o.maintenances["7"] = params
+		reply(map[string][]string{"maintenanceids": {"7"}})
+	case "maintenance.delete":
+		var ids []string
+		_ = json.Unmarshal(req.Params, &ids)
+
+		for _, id := range ids {
+			if _, ok := o.maintenances[id]; !ok {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"jsonrpc": "2.0",
+					"error":   map[string]interface{}{"code": -32602, "message": "Invalid params.", "data": "No permissions."},
+					"id":      req.ID,
+				})
+
+				return
+			}
+
+			delete(o.maintenances, id)
+		}
+
+		reply(map[string][]string{"maintenanceids": ids})
+	default:
+		http.Error(w, "unknown method", http.StatusBadRequest)
+	}
+}
+
+func TestZabbix_CreateExpire(t *testing.T) {
+	fake := &fakeZabbix{maintenances: map[string]map[string]interface{}{}}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	target, err := sinks.NewZabbix(srv.URL+"/api_jsonrpc.php", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2026, 11, 5, 23, 0, 0, 0, time.UTC)
+	window := models.Window{
+		Section: &models.SheduleSection{Zabbix: &models.ZabbixTarget{Groups: []string{"Windows servers"}}},
+		Shedule: &models.Shedule{Hosts: []string{"udbs01", "udbs02"}},
+		Silence: models.Silence{Comment: "udbs01 | SCCM-Updates-MW_1_Thu_02 | 03:00:00"},
+		Start:   start,
+		End:     start.Add(3 * time.Hour),
+	}
+
+	id, err := target.Create(context.Background(), window)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	created := fake.maintenances[id]
+	if created == nil {
+		t.Fatalf("Create() id = %v, maintenance not stored", id)
+	}
+
+	if created["active_since"].(float64) != float64(start.Unix()) ||
+		len(created["groups"].([]interface{})) != 1 || len(created["hosts"].([]interface{})) != 2 {
+		t.Errorf("Create() maintenance = %v", created)
+	}
+
+	period := created["timeperiods"].([]interface{})[0].(map[string]interface{})
+	if period["period"].(float64) != 3*3600 {
+		t.Errorf("Create() timeperiod = %v", period)
+	}
+
+	if err := target.Expire(context.Background(), window, id); err != nil {
+		t.Fatalf("Expire() error = %v", err)
+	}
+
+	if err := target.Expire(context.Background(), window, id); err == nil {
+		t.Error("Expire() of deleted maintenance error = nil, want JSON-RPC error")
+	}
+}
+
+func TestZabbix_CreateNoHosts(t *testing.T) {
+	target, err := sinks.NewZabbix("http://127.0.0.1:1/api_jsonrpc.php", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := target.Create(context.Background(), models.Window{}); err == nil {
+		t.Error("Create() error = nil, want error for window without hosts")
+	}
+}
@@ -1,7 +1,10 @@
 package stats
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -13,13 +16,13 @@ const statsCount = 500
 
 // Instance struct for web statistic of runned service.
 type Instance struct {
-	RecvStat        chan bool
-	SetOK           chan bool
 	port            string
+	mux             sync.Mutex // Guards stat, statsCountIndex and sheds.
 	stat            [statsCount]string
 	statsCountIndex int
-	sheds           []string
+	sheds           func() []string // Source of runned shedules report.
 	logger          *zap.Logger
+	handlers        *http.ServeMux // Handlers of statistic port, not shared with metrics port.
 	srv             *http.Server
 }
 
@@ -28,9 +31,8 @@ func NewInstance(port string, logger *zap.Logger) *Instance {
 	var result Instance
 	result.port = port
 	result.logger = logger
-	result.srv = &http.Server{Addr: ":" + port}
-	result.RecvStat = make(chan bool)
-	result.SetOK = make(chan bool)
+	result.handlers = http.NewServeMux()
+	result.srv = &http.Server{Addr: ":" + port, Handler: result.handlers}
 
 	return &result
 }
@@ -39,7 +41,7 @@ func NewInstance(port string, logger *zap.Logger) *Instance {
 func (o *Instance) Run() {
 	go func() {
 		err := o.serve()
-		if err != nil {
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			o.logger.Sugar().Info("Stats HTTP server exit, err: ", err)
 		}
 	}()
@@ -50,71 +52,71 @@ func (o *Instance) Stop() {
 	o.srv.Close()
 }
 
+// Shutdown gracefully stop statistic server, waiting for active requests until ctx is done.
+func (o *Instance) Shutdown(ctx context.Context) error {
+	return o.srv.Shutdown(ctx)
+}
+
 func (o *Instance) serve() error {
-	http.Handle("/stats", promhttp.InstrumentHandlerCounter(
-		promauto.NewCounterVec(
-			prometheus.CounterOpts{
-				Name: "silences_sheduler_requests_statistics_total",
-				Help: "Total number of httpoint requests by HTTP code.",
-			},
-			[]string{"code"},
-		),
-		http.HandlerFunc(o.getStats),
-	))
+	o.Handle("/stats", "silences_sheduler_requests_statistics_total", http.HandlerFunc(o.ServeStats))
+	o.Handle("/shedules", "silences_sheduler_requests_shedules_total", http.HandlerFunc(o.ServeShedules))
 
-	http.Handle("/shedules", promhttp.InstrumentHandlerCounter(
+	return o.srv.ListenAndServe()
+}
+
+// Handle register additional handler for pattern on statistic port, with counter of requests by HTTP code.
+func (o *Instance) Handle(pattern, counterName string, handler http.Handler) {
+	o.handlers.Handle(pattern, promhttp.InstrumentHandlerCounter(
 		promauto.NewCounterVec(
 			prometheus.CounterOpts{
-				Name: "silences_sheduler_requests_shedules_total",
+				Name: counterName,
 				Help: "Total number of httpoint requests by HTTP code.",
 			},
 			[]string{"code"},
 		),
-		http.HandlerFunc(o.getShedules),
+		handler,
 	))
-
-	return o.srv.ListenAndServe()
 }
 
-func (o *Instance) getStats(w http.ResponseWriter, r *http.Request) {
-	_, err := w.Write([]byte("Data Post Silence;Silence StartsAt;Silence EndsAt;Silence Comment;Silence Matchers\n"))
+// ServeStats write last runs of shedules, oldest first.
+func (o *Instance) ServeStats(w http.ResponseWriter, r *http.Request) {
+	_, err := w.Write([]byte("Data Post Silence;Sink;Tenant;Silence StartsAt;Silence EndsAt;Silence Comment;Silence Matchers;User\n"))
 	if err != nil {
 		o.logger.Sugar().Errorf("write in http.ResponseWriter failed: error %v", err)
 		return
 	}
-	// first print old stats (after o.shedCountIndex)
-	for _, stat := range o.stat[o.statsCountIndex:] {
-		if stat == "" {
-			break
-		}
 
+	for _, stat := range o.Stats() {
 		_, err := w.Write([]byte(stat))
 		if err != nil {
 			o.logger.Sugar().Errorf("write in http.ResponseWriter failed: error %v", err)
 			return
 		}
 	}
+}
 
-	// second print new stats (before o.shedCountIndex)
-	if o.statsCountIndex == 0 { // all records already printed
-		return
-	}
+// Stats return copy of last runs of shedules, oldest first.
+func (o *Instance) Stats() []string {
+	o.mux.Lock()
+	defer o.mux.Unlock()
 
-	for _, stat := range o.stat[:o.statsCountIndex] {
-		if stat == "" {
-			continue
-		}
+	result := make([]string, 0, statsCount)
 
-		_, err := w.Write([]byte(stat))
-		if err != nil {
-			o.logger.Sugar().Errorf("write in http.ResponseWriter failed: error %v", err)
-			return
+	// first old stats (after o.statsCountIndex), then new stats (before o.statsCountIndex).
+	for _, stat := range append(o.stat[o.statsCountIndex:], o.stat[:o.statsCountIndex]...) {
+		if stat != "" {
+			result = append(result, stat)
 		}
 	}
+
+	return result
 }
 
-// AddSheduleRun increase statistic of shedule run.
+// AddSheduleRun increase statistic of shedule run, safe for concurrent use.
 func (o *Instance) AddSheduleRun(stat string) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
 	o.stat[o.statsCountIndex] = stat
 
 	o.statsCountIndex++
@@ -123,11 +125,17 @@ func (o *Instance) AddSheduleRun(stat string) {
 	}
 }
 
-func (o *Instance) getShedules(w http.ResponseWriter, r *http.Request) {
-	o.RecvStat <- true
-	<-o.SetOK
+// ServeShedules write report of runned shedules.
+func (o *Instance) ServeShedules(w http.ResponseWriter, r *http.Request) {
+	o.mux.Lock()
+	sheds := o.sheds
+	o.mux.Unlock()
 
-	for _, shed := range o.sheds {
+	if sheds == nil {
+		return
+	}
+
+	for _, shed := range sheds() {
 		_, err := w.Write([]byte(shed))
 		if err != nil {
 			o.logger.Sugar().Errorf("write in http.ResponseWriter failed: error %v", err)
@@ -136,7 +144,10 @@ func (o *Instance) getShedules(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// SetShedules update info of runned shedules.
-func (o *Instance) SetShedules(sheds []string) {
+// SetShedules set source of report of runned shedules, it is called on every request of report.
+func (o *Instance) SetShedules(sheds func() []string) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
 	o.sheds = sheds
 }
@@ -0,0 +1,69 @@
+package stats_test
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/stats"
+	"go.uber.org/zap"
+)
+
+func TestInstance_Stats(t *testing.T) {
+	stat := stats.NewInstance("0", zap.NewNop())
+
+	for i := 0; i < 510; i++ {
+		stat.AddSheduleRun(fmt.Sprintf("%v\n", i))
+	}
+
+	got := stat.Stats()
+	if len(got) != 500 || got[0] != "10\n" || got[499] != "509\n" {
+		t.Errorf("Stats() = %v ... %v (%v records), want 10 ... 509", got[0], got[len(got)-1], len(got))
+	}
+}
+
+func TestInstance_Concurrent(t *testing.T) {
+	stat := stats.NewInstance("0", zap.NewNop())
+	stat.SetShedules(func() []string { return []string{"header\n"} })
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 200; i++ {
+		wg.Add(3)
+
+		go func(i int) {
+			defer wg.Done()
+			stat.AddSheduleRun(fmt.Sprintf("run %v\n", i))
+		}(i)
+
+		go func() {
+			defer wg.Done()
+
+			w := httptest.NewRecorder()
+			stat.ServeStats(w, httptest.NewRequest("GET", "/stats", nil))
+
+			if !strings.HasPrefix(w.Body.String(), "Data Post Silence;") {
+				t.Errorf("ServeStats() = %q", w.Body.String())
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			w := httptest.NewRecorder()
+			stat.ServeShedules(w, httptest.NewRequest("GET", "/shedules", nil))
+
+			if w.Body.String() != "header\n" {
+				t.Errorf("ServeShedules() = %q", w.Body.String())
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if got := len(stat.Stats()); got != 200 {
+		t.Errorf("len(Stats()) = %v, want 200", got)
+	}
+}
@@ -6,12 +6,19 @@
 // in shedule field, split by '_':
 // "backup_system_name",
 // week number in month
-// day in week
+// day in week, may have offset in days from this weekday: "Tue+5"
 // hour
+// Or, relative to Patch Tuesday (second Tuesday of month): "SCCM-Updates-MW_PT+5_02"
+// "backup_system_name",
+// "PT" with offset in days
+// hour
+// Hostname field used as Zabbix host of shedule, Zabbix maintenance periods created if csv_zabbix parameter is "true".
+// Silence comment and createdBy are Go templates, fields of CSV line available as .Vars.host, .Vars.code and .Vars.offset.
 
 package storages
 
 import (
+	"context"
 	"encoding/csv"
 	"encoding/hex"
 	"fmt"
@@ -28,12 +35,21 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	csvDefaultComment   = "{{.Vars.host}} | {{.Vars.code}} | {{.Vars.offset}}"
+	csvDefaultCreatedBy = "SilenceSheduler"
+)
+
 // CSVstorage implementation persing CVS config files.
 type CSVstorage struct {
 	directoryName  string // Directory with shedules configs.
 	updateInterval int    // Update interval of config from files
+	comment        string // Template of silence comment.
+	createdBy      string // Template of silence createdBy.
+	zabbix         bool   // Create Zabbix maintenance periods for hosts.
 	sheds          map[string]bool
 	logger         *zap.Logger
+	done           chan struct{} // Closed when storage stopped.
 }
 
 // GetCSVStorage return configured CVS storage.
@@ -60,6 +76,18 @@ func GetCSVStorage(config map[string]string, logger *zap.Logger) (*CSVstorage, e
 		logger.Sugar().Errorf("parsing 'update_interval' parameter: %v error: %v", intrvl, err)
 	}
 
+	storage.comment = csvDefaultComment
+	if comment, ok := config["csv_comment"]; ok && comment != "" {
+		storage.comment = comment
+	}
+
+	storage.createdBy = csvDefaultCreatedBy
+	if createdBy, ok := config["csv_created_by"]; ok && createdBy != "" {
+		storage.createdBy = createdBy
+	}
+
+	storage.zabbix = config["csv_zabbix"] == "true"
+
 	storage.sheds = make(map[string]bool)
 	storage.logger = logger
 
@@ -67,8 +95,14 @@ func GetCSVStorage(config map[string]string, logger *zap.Logger) (*CSVstorage, e
 }
 
 // Run parsing and check of updates cvs files.
-func (o *CSVstorage) Run(add chan models.SheduleSection, del chan string) {
-	go o.run(add, del)
+func (o *CSVstorage) Run(ctx context.Context, add chan models.SheduleSection, del chan string) {
+	o.done = make(chan struct{})
+	go o.run(ctx, add, del)
+}
+
+// Done return channel closed when storage stopped after cancel of context of Run.
+func (o *CSVstorage) Done() <-chan struct{} {
+	return o.done
 }
 
 // FillAllShedules parse files.
@@ -119,10 +153,10 @@ func (o *CSVstorage) decode(file io.Reader,
 
 	var sheduleTemplate = models.Shedule{
 		Cron:     "",
-		Duration: 10800, // 3 hours in sec.
+		Duration: models.Duration(3 * time.Hour),
 		Silence: models.Silence{
-			Comment:   "Automatic silence for OS Update 3h",
-			CreatedBy: "SilenceSheduler",
+			Comment:   o.comment,
+			CreatedBy: o.createdBy,
 			Matchers: []models.Matchers{
 				{IsEqual: true, IsRegex: true, Name: "hostname", Value: "~"},
 			},
@@ -155,10 +189,15 @@ func (o *CSVstorage) decode(file io.Reader,
 		token := fileName + "|" + info.ModTime().String() + location.String()
 		shedd[len(shedd)-1].SetToken(hex.EncodeToString(mmh3.Hash128([]byte(token)).Bytes()))
 		shedd[len(shedd)-1].SetSectionName(info.Name())
+		shedd[len(shedd)-1].SetFilePath(fileName)
 
 		_, offset := time.Now().In(location).Zone()
 		shedd[len(shedd)-1].TimeOffset = fmt.Sprint(offset / 60 / 60)
 
+		if o.zabbix {
+			shedd[len(shedd)-1].Zabbix = &models.ZabbixTarget{}
+		}
+
 		for _, line := range shedSect {
 			rec := sheduleTemplate
 
@@ -174,25 +213,18 @@ func (o *CSVstorage) decode(file io.Reader,
 			rec.Silence.Matchers[0].Value = line[0] + ".+"
 
 			// Set Cron shedule
-			timeArr := strings.Split(line[1], "_")
-			if len(timeArr) < 3 {
-				continue
-			}
-
-			weeknum, err := strconv.Atoi(timeArr[len(timeArr)-3])
-			if err != nil {
+			cronSpec, ok := csvCron(line[1])
+			if !ok {
 				continue
 			}
 
-			dow := timeArr[len(timeArr)-2]
-			hour, err := strconv.Atoi(timeArr[len(timeArr)-1])
-
-			if err != nil {
-				continue
+			rec.Cron = cronSpec
+			rec.Hosts = []string{line[0]}
+			rec.Vars = map[string]string{
+				"host":   line[0],
+				"code":   line[1],
+				"offset": line[2],
 			}
-
-			rec.Cron = "0 0 " + fmt.Sprint(hour) + " * * " + dow + "#" + fmt.Sprint(weeknum)
-			rec.Silence.Comment = line[0] + " | " + line[1] + " | " + line[2]
 			shedd[len(shedd)-1].Shedules = append(shedd[len(shedd)-1].Shedules, rec)
 
 			fmt.Println(rec.String())
@@ -202,8 +234,65 @@ func (o *CSVstorage) decode(file io.Reader,
 	return shedd
 }
 
-func (o *CSVstorage) run(add chan models.SheduleSection, del chan string) {
-	err := o.update(add, del)
+// csvCron convert maintenance window code into cron or relative expression.
+func csvCron(code string) (string, bool) {
+	timeArr := strings.Split(code, "_")
+	if len(timeArr) < 2 {
+		return "", false
+	}
+
+	hour, err := strconv.Atoi(timeArr[len(timeArr)-1])
+	if err != nil {
+		return "", false
+	}
+
+	dow := timeArr[len(timeArr)-2]
+
+	// Relative to Patch Tuesday: PT+5
+	if strings.HasPrefix(strings.ToUpper(dow), "PT") {
+		days, err := csvDays(dow[2:])
+		if err != nil {
+			return "", false
+		}
+
+		return fmt.Sprintf("@patchtuesday %+d %02d:00", days, hour), true
+	}
+
+	if len(timeArr) < 3 {
+		return "", false
+	}
+
+	weeknum, err := strconv.Atoi(timeArr[len(timeArr)-3])
+	if err != nil {
+		return "", false
+	}
+
+	// Weekday with offset in days: Tue+5
+	if i := strings.IndexAny(dow, "+-"); i > 0 {
+		days, err := csvDays(dow[i:])
+		if err != nil {
+			return "", false
+		}
+
+		return fmt.Sprintf("@nth %d %s %+d %02d:00", weeknum, strings.ToLower(dow[:i]), days, hour), true
+	}
+
+	return "0 0 " + fmt.Sprint(hour) + " * * " + dow + "#" + fmt.Sprint(weeknum), true
+}
+
+// csvDays parse offset in days, "" mean no offset.
+func csvDays(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(value)
+}
+
+func (o *CSVstorage) run(ctx context.Context, add chan models.SheduleSection, del chan string) {
+	defer close(o.done)
+
+	err := o.update(ctx, add, del)
 	if err != nil {
 		return
 	}
@@ -212,17 +301,22 @@ func (o *CSVstorage) run(add chan models.SheduleSection, del chan string) {
 	defer tim.Stop()
 
 	for {
-		t := <-tim.C
-		o.logger.Sugar().Infof("Tick on %v", t)
-
-		err := o.update(add, del)
-		if err != nil {
+		select {
+		case <-ctx.Done():
+			o.logger.Sugar().Infof("Storage of %v stopped", o.directoryName)
 			return
+		case t := <-tim.C:
+			o.logger.Sugar().Infof("Tick on %v", t)
+
+			err := o.update(ctx, add, del)
+			if err != nil {
+				return
+			}
 		}
 	}
 }
 
-func (o *CSVstorage) update(add chan models.SheduleSection, del chan string) error {
+func (o *CSVstorage) update(ctx context.Context, add chan models.SheduleSection, del chan string) error {
 	allShed, err := o.FillAllShedules()
 	if err != nil {
 		return err
@@ -233,7 +327,9 @@ func (o *CSVstorage) update(add chan models.SheduleSection, del chan string) err
 	// Add New shedules.
 	for _, val := range allShed {
 		if _, ok := o.sheds[val.GetToken()]; !ok {
-			add <- val
+			if err := send(ctx, add, val); err != nil {
+				return err
+			}
 
 			o.sheds[val.GetToken()] = true
 		}
@@ -244,7 +340,10 @@ func (o *CSVstorage) update(add chan models.SheduleSection, del chan string) err
 	// Remove non existent Shedules.
 	for key := range o.sheds {
 		if _, ok := newShed[key]; !ok {
-			del <- key
+			if err := send(ctx, del, key); err != nil {
+				return err
+			}
+
 			delete(o.sheds, key)
 		}
 	}
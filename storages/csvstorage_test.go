@@ -0,0 +1,56 @@
+package storages_test
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/storages"
+	"go.uber.org/zap"
+)
+
+func TestCSVstorage_FillAllShedules(t *testing.T) {
+	dir := t.TempDir()
+	csv := `"hostname","shedule","timeshift"
+"udbs01","SCCM-Updates-MW_1_Thu_02","03:00:00"
+"udbs02","SCCM-Updates-MW_PT+5_02","03:00:00"
+"udbs03","SCCM-Updates-MW_2_Tue-1_23","03:00:00"
+"udbs04","SCCM-Updates-MW_bad","03:00:00"
+`
+
+	if err := os.WriteFile(filepath.Join(dir, "sccm.csv"), []byte(csv), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	storage, err := storages.GetCSVStorage(map[string]string{"shedules_dir": dir, "update_interval": "1"}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sections, err := storage.FillAllShedules()
+	if err != nil {
+		t.Fatalf("FillAllShedules() error = %v", err)
+	}
+
+	var crons []string
+
+	for _, section := range sections {
+		for _, shed := range section.Shedules {
+			crons = append(crons, shed.Cron)
+		}
+	}
+
+	sort.Strings(crons)
+
+	want := []string{"0 0 2 * * Thu#1", "@nth 2 tue -1 23:00", "@patchtuesday +5 02:00"}
+	if len(crons) != len(want) {
+		t.Fatalf("crons = %v, want %v", crons, want)
+	}
+
+	for i := range want {
+		if crons[i] != want[i] {
+			t.Errorf("crons = %v, want %v", crons, want)
+		}
+	}
+}
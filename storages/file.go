@@ -0,0 +1,66 @@
+package storages
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"go.uber.org/zap"
+)
+
+// ReadFile parse sections from one file of any storage, storage selected by file extension (.yaml, .csv, .ics).
+// File may be outside of shedules directory, config is same as for storages.
+func ReadFile(fileName string, config map[string]string, logger *zap.Logger) ([]models.SheduleSection, error) {
+	info, err := os.Stat(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	switch filepath.Ext(fileName) {
+	case ".yaml":
+		storage, err := GetYAMLStorage(config, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		section, err := storage.fillShedule(fileName, info)
+		if err != nil {
+			return nil, err
+		}
+
+		return []models.SheduleSection{*section}, nil
+	case ".csv":
+		storage, err := GetCSVStorage(config, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		return storage.fillShedule(fileName, info)
+	case ".ics":
+		storage, err := GetICSStorage(config, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		section, err := storage.fillShedule(fileName, info)
+		if err != nil {
+			return nil, err
+		}
+
+		return []models.SheduleSection{*section}, nil
+	}
+
+	return nil, fmt.Errorf("unknown type of file %v, want .yaml, .csv or .ics", fileName)
+}
+
+// send value to runner channel, return error of ctx if it is done before runner receive value.
+func send[T any](ctx context.Context, ch chan T, value T) error {
+	select {
+	case ch <- value:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
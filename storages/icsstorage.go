@@ -0,0 +1,326 @@
+// Storage that periodicaly load shedules from iCalendar (.ics) files in specified derectory.
+// Every VEVENT with matchers is a shedule, recurring events (RRULE, EXDATE, TZID) are repeated silences,
+// single events are one-off silences. Matchers and silence fields are set in event properties:
+//  X-SILENCE-MATCHER:productname=~"AD.*"     (one property per matcher)
+//  X-SILENCE-COMMENT:Backup window           (default SUMMARY)
+//  X-SILENCE-CREATEDBY:Change calendar       (default SilenceSheduler)
+// or in sidecar mapping file <file>.icsmap (YAML): section fields (tenant, sink, globalmatchers, webhooks, ...)
+// and list of events, matched by UID or regexp of SUMMARY:
+//  events:
+//    - summary: '^Backup'
+//      matchers: [{isEqual: true, isRegex: false, name: alertname, value: DiskLatency1s}]
+// Events without matchers are skipped, so .ics files of exclusion calendars don't produce shedules.
+
+package storages
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/ical"
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/datadog/mmh3"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	icsMappingExt    = ".icsmap"
+	icsDefaultAuthor = "SilenceSheduler"
+)
+
+// ICSstorage implementation persing iCalendar files.
+type ICSstorage struct {
+	directoryName  string // Directory with shedules configs.
+	updateInterval int    // Update interval of config from files
+	sheds          map[string]bool
+	logger         *zap.Logger
+	done           chan struct{} // Closed when storage stopped.
+}
+
+// icsMapping sidecar file with section fields and matchers of events.
+type icsMapping struct {
+	models.SheduleSection `yaml:",inline"`
+	Events                []icsEventMapping `yaml:"events"`
+}
+
+// icsEventMapping silence fields for events with UID or SUMMARY.
+type icsEventMapping struct {
+	UID       string             `yaml:"uid"`       // UID of event.
+	Summary   string             `yaml:"summary"`   // Regexp of event SUMMARY.
+	Matchers  models.MatcherList `yaml:"matchers"`  // Matchers of silence.
+	Comment   string             `yaml:"comment"`   // Comment of silence, default SUMMARY.
+	CreatedBy string             `yaml:"createdBy"` // Author of silence.
+	summary   *regexp.Regexp
+}
+
+// GetICSStorage return configured iCalendar storage.
+func GetICSStorage(config map[string]string, logger *zap.Logger) (*ICSstorage, error) {
+	var (
+		storage ICSstorage
+		err     error
+	)
+
+	dirName, ok := config["shedules_dir"]
+	if !ok {
+		return nil, fmt.Errorf("config Param -shedules_dir- not found")
+	}
+
+	storage.directoryName = dirName
+
+	intrvl, ok := config["update_interval"]
+	if !ok {
+		return nil, fmt.Errorf("config Param -update_interval- not found")
+	}
+
+	storage.updateInterval, err = strconv.Atoi(intrvl)
+	if err != nil {
+		logger.Sugar().Errorf("parsing 'update_interval' parameter: %v error: %v", intrvl, err)
+	}
+
+	storage.sheds = make(map[string]bool)
+	storage.logger = logger
+
+	return &storage, nil
+}
+
+// Run parsing and update checking of ics files.
+func (o *ICSstorage) Run(ctx context.Context, add chan models.SheduleSection, del chan string) {
+	o.done = make(chan struct{})
+	go o.run(ctx, add, del)
+}
+
+// Done return channel closed when storage stopped after cancel of context of Run.
+func (o *ICSstorage) Done() <-chan struct{} {
+	return o.done
+}
+
+// FillAllShedules parse all shedules from ics files.
+func (o *ICSstorage) FillAllShedules() (shedules map[string]models.SheduleSection, err error) {
+	shedules = make(map[string]models.SheduleSection)
+
+	err = filepath.Walk(o.directoryName,
+		func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if info.IsDir() || filepath.Ext(path) != ".ics" {
+				return nil
+			}
+
+			shedSection, err := o.fillShedule(path, info)
+			if err != nil {
+				o.logger.Sugar().Errorf("decode file '%v' error: %v", path, err)
+				return nil
+			}
+
+			if len(shedSection.Shedules) > 0 {
+				shedules[shedSection.GetToken()] = *shedSection
+			}
+
+			return nil
+		})
+
+	return
+}
+
+func (o *ICSstorage) fillShedule(fileName string, info os.FileInfo) (*models.SheduleSection, error) {
+	var mapping icsMapping
+
+	token := fileName + "|" + info.ModTime().String()
+
+	mappingInfo, err := os.Stat(fileName + icsMappingExt)
+	if err == nil {
+		token += "|" + mappingInfo.ModTime().String()
+
+		if err := o.readMapping(fileName+icsMappingExt, &mapping); err != nil {
+			return nil, err
+		}
+	}
+
+	shedSect := mapping.SheduleSection
+	shedSect.Shedules = nil
+	shedSect.SetToken(hex.EncodeToString(mmh3.Hash128([]byte(token)).Bytes()))
+	shedSect.SetSectionName(info.Name())
+	shedSect.SetFilePath(fileName)
+
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	events, err := ical.Parse(file)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		shed, ok, err := icsShedule(event, mapping.Events)
+		if err != nil {
+			o.logger.Sugar().Errorf("event %q in file '%v' error: %v", event.Summary, fileName, err)
+			continue
+		}
+
+		if ok {
+			shedSect.Shedules = append(shedSect.Shedules, shed)
+		}
+	}
+
+	return &shedSect, nil
+}
+
+func (o *ICSstorage) readMapping(fileName string, mapping *icsMapping) error {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := yaml.NewDecoder(file).Decode(mapping); err != nil {
+		return fmt.Errorf("decode mapping '%v': %w", fileName, err)
+	}
+
+	for i := range mapping.Events {
+		if mapping.Events[i].Summary == "" {
+			continue
+		}
+
+		re, err := regexp.Compile(mapping.Events[i].Summary)
+		if err != nil {
+			return fmt.Errorf("mapping '%v': %w", fileName, err)
+		}
+
+		mapping.Events[i].summary = re
+	}
+
+	return nil
+}
+
+// icsShedule convert event into shedule, return false for event without matchers.
+func icsShedule(event ical.Event, mappings []icsEventMapping) (models.Shedule, bool, error) {
+	var shed models.Shedule
+
+	shed.Silence.Comment = event.Summary
+	shed.Silence.CreatedBy = icsDefaultAuthor
+
+	for _, mapping := range mappings {
+		if (mapping.UID != "" && mapping.UID == event.UID) || (mapping.summary != nil && mapping.summary.MatchString(event.Summary)) {
+			shed.Silence.Matchers = append(shed.Silence.Matchers, mapping.Matchers...)
+
+			if mapping.Comment != "" {
+				shed.Silence.Comment = mapping.Comment
+			}
+
+			if mapping.CreatedBy != "" {
+				shed.Silence.CreatedBy = mapping.CreatedBy
+			}
+
+			break
+		}
+	}
+
+	for _, value := range event.PropValues("X-SILENCE-MATCHER") {
+		matcher, err := models.ParseMatcher(value)
+		if err != nil {
+			return shed, false, err
+		}
+
+		shed.Silence.Matchers = append(shed.Silence.Matchers, matcher)
+	}
+
+	if comment := event.Prop("X-SILENCE-COMMENT"); comment != "" {
+		shed.Silence.Comment = comment
+	}
+
+	if createdBy := event.Prop("X-SILENCE-CREATEDBY"); createdBy != "" {
+		shed.Silence.CreatedBy = createdBy
+	}
+
+	if len(shed.Silence.Matchers) == 0 {
+		return shed, false, nil
+	}
+
+	if !event.Recurring() {
+		shed.Start = event.Start.Format(time.RFC3339)
+		shed.End = event.End.Format(time.RFC3339)
+
+		return shed, true, nil
+	}
+
+	if event.Duration() <= 0 {
+		return shed, false, fmt.Errorf("recurring event without duration")
+	}
+
+	shed.Cron = "RRULE:" + strings.TrimPrefix(event.Prop("RRULE"), "RRULE:")
+	shed.Duration = models.Duration(event.Duration())
+	shed.SetSchedule(event)
+
+	return shed, true, nil
+}
+
+func (o *ICSstorage) run(ctx context.Context, add chan models.SheduleSection, del chan string) {
+	defer close(o.done)
+
+	err := o.update(ctx, add, del)
+	if err != nil {
+		return
+	}
+
+	tim := time.NewTicker(time.Second * time.Duration(o.updateInterval))
+	defer tim.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			o.logger.Sugar().Infof("Storage of %v stopped", o.directoryName)
+			return
+		case t := <-tim.C:
+			o.logger.Sugar().Infof("Tick on %v", t)
+
+			err := o.update(ctx, add, del)
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (o *ICSstorage) update(ctx context.Context, add chan models.SheduleSection, del chan string) error {
+	newShed, err := o.FillAllShedules()
+	if err != nil {
+		return err
+	}
+
+	// Add New shedules.
+	for key, val := range newShed {
+		if _, ok := o.sheds[key]; !ok {
+			if err := send(ctx, add, val); err != nil {
+				return err
+			}
+
+			o.sheds[key] = true
+		}
+	}
+
+	// Remove non existent Shedules.
+	for key := range o.sheds {
+		if _, ok := newShed[key]; !ok {
+			if err := send(ctx, del, key); err != nil {
+				return err
+			}
+
+			delete(o.sheds, key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package storages_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/storages"
+	"go.uber.org/zap"
+)
+
+func TestICSstorage_FillAllShedules(t *testing.T) {
+	dir := t.TempDir()
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:backup-1\r\n" +
+		"SUMMARY:Nightly backup\r\n" +
+		"DTSTART:20260105T020000Z\r\n" +
+		"DTEND:20260105T040000Z\r\n" +
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,WE\r\n" +
+		"X-SILENCE-MATCHER:alertname=\"DiskLatency1s\"\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:migration-1\r\n" +
+		"SUMMARY:DB migration\r\n" +
+		"DTSTART:20260110T220000Z\r\n" +
+		"DTEND:20260111T010000Z\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:meeting-1\r\n" +
+		"SUMMARY:Team meeting\r\n" +
+		"DTSTART:20260110T100000Z\r\n" +
+		"DTEND:20260110T110000Z\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	mapping := `tenant: team-a
+events:
+  - summary: '^DB migration'
+    comment: Migration window
+    matchers:
+      - {isEqual: true, isRegex: false, name: service, value: db}
+`
+
+	if err := os.WriteFile(filepath.Join(dir, "changes.ics"), []byte(ics), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "changes.ics.icsmap"), []byte(mapping), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	storage, err := storages.GetICSStorage(map[string]string{"shedules_dir": dir, "update_interval": "1"}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sections, err := storage.FillAllShedules()
+	if err != nil {
+		t.Fatalf("FillAllShedules() error = %v", err)
+	}
+
+	if len(sections) != 1 {
+		t.Fatalf("sections = %v, want 1", len(sections))
+	}
+
+	for _, section := range sections {
+		if section.Tenant != "team-a" || section.GetSectionName() != "changes.ics" {
+			t.Errorf("section tenant %q name %q", section.Tenant, section.GetSectionName())
+		}
+
+		if len(section.Shedules) != 2 {
+			t.Fatalf("shedules = %v, want 2", len(section.Shedules))
+		}
+
+		backup := section.Shedules[0]
+		if backup.Duration != 2*60*60*1e9 || backup.Silence.Comment != "Nightly backup" {
+			t.Errorf("recurring shedule = %v", backup)
+		}
+
+		schedule, err := backup.Schedule()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		from := time.Date(2026, 1, 5, 3, 0, 0, 0, time.UTC)
+		if next := schedule.Next(from); !next.Equal(time.Date(2026, 1, 7, 2, 0, 0, 0, time.UTC)) {
+			t.Errorf("Next(%v) = %v", from, next)
+		}
+
+		migration := section.Shedules[1]
+		if !migration.IsOneOff() || migration.Silence.Comment != "Migration window" || migration.Silence.Matchers[0].Value != "db" {
+			t.Errorf("one-off shedule = %v", migration)
+		}
+	}
+}
@@ -2,6 +2,7 @@
 package storages
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"os"
@@ -21,6 +22,7 @@ type YAMLstorage struct {
 	updateInterval int    // Update interval of config from files
 	sheds          map[string]bool
 	logger         *zap.Logger
+	done           chan struct{} // Closed when storage stopped.
 }
 
 // GetYAMLStorage return configured yaml storage.
@@ -54,8 +56,14 @@ func GetYAMLStorage(config map[string]string, logger *zap.Logger) (*YAMLstorage,
 }
 
 // Run parsing and update checking of yaml files.
-func (o *YAMLstorage) Run(add chan models.SheduleSection, del chan string) {
-	go o.run(add, del)
+func (o *YAMLstorage) Run(ctx context.Context, add chan models.SheduleSection, del chan string) {
+	o.done = make(chan struct{})
+	go o.run(ctx, add, del)
+}
+
+// Done return channel closed when storage stopped after cancel of context of Run.
+func (o *YAMLstorage) Done() <-chan struct{} {
+	return o.done
 }
 
 // FillAllShedules parse all shedules from yaml file.
@@ -89,6 +97,7 @@ func (o *YAMLstorage) fillShedule(fileName string, info os.FileInfo) (*models.Sh
 
 	shedSect.SetToken(hex.EncodeToString(mmh3.Hash128([]byte(token)).Bytes()))
 	shedSect.SetSectionName(info.Name())
+	shedSect.SetFilePath(fileName)
 
 	file, err := os.Open(fileName)
 	if err != nil {
@@ -104,11 +113,21 @@ func (o *YAMLstorage) fillShedule(fileName string, info os.FileInfo) (*models.Sh
 		return nil, err
 	}
 
+	if err := shedSect.CheckTemplates(); err != nil {
+		o.logger.Sugar().Errorf("silence templates in file '%v' error: %v", fileName, err)
+	}
+
+	if err := shedSect.CheckWindows(time.Now()); err != nil {
+		o.logger.Sugar().Errorf("shedule windows in file '%v' error: %v", fileName, err)
+	}
+
 	return &shedSect, nil
 }
 
-func (o *YAMLstorage) run(add chan models.SheduleSection, del chan string) {
-	err := o.update(add, del)
+func (o *YAMLstorage) run(ctx context.Context, add chan models.SheduleSection, del chan string) {
+	defer close(o.done)
+
+	err := o.update(ctx, add, del)
 	if err != nil {
 		return
 	}
@@ -117,17 +136,22 @@ func (o *YAMLstorage) run(add chan models.SheduleSection, del chan string) {
 	defer tim.Stop()
 
 	for {
-		t := <-tim.C
-		o.logger.Sugar().Infof("Tick on %v", t)
-
-		err := o.update(add, del)
-		if err != nil {
+		select {
+		case <-ctx.Done():
+			o.logger.Sugar().Infof("Storage of %v stopped", o.directoryName)
 			return
+		case t := <-tim.C:
+			o.logger.Sugar().Infof("Tick on %v", t)
+
+			err := o.update(ctx, add, del)
+			if err != nil {
+				return
+			}
 		}
 	}
 }
 
-func (o *YAMLstorage) update(add chan models.SheduleSection, del chan string) error {
+func (o *YAMLstorage) update(ctx context.Context, add chan models.SheduleSection, del chan string) error {
 	newShed, err := o.FillAllShedules()
 	if err != nil {
 		return err
@@ -136,7 +160,9 @@ func (o *YAMLstorage) update(add chan models.SheduleSection, del chan string) er
 	// Add New shedules.
 	for key, val := range newShed {
 		if _, ok := o.sheds[key]; !ok {
-			add <- val
+			if err := send(ctx, add, val); err != nil {
+				return err
+			}
 
 			o.sheds[key] = true
 		}
@@ -145,7 +171,10 @@ func (o *YAMLstorage) update(add chan models.SheduleSection, del chan string) er
 	// Remove non existent Shedules.
 	for key := range o.sheds {
 		if _, ok := newShed[key]; !ok {
-			del <- key
+			if err := send(ctx, del, key); err != nil {
+				return err
+			}
+
 			delete(o.sheds, key)
 		}
 	}
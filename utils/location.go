@@ -9,6 +9,7 @@ import (
 
 // GetLocation convert offset into Location.
 // inOffset = "hh24:mm:ss:", mean offset from UTC, func use only hour (hh24). Or, int value interpreted as osset too.
+// Or, IANA time zone name, like "Europe/Moscow".
 func GetLocation(inOffset string) *time.Location {
 	tLocal := time.Local
 
@@ -16,6 +17,12 @@ func GetLocation(inOffset string) *time.Location {
 		return tLocal
 	}
 
+	if strings.Contains(inOffset, "/") || inOffset == "UTC" {
+		if loc, err := time.LoadLocation(inOffset); err == nil {
+			return loc
+		}
+	}
+
 	TimeOffset := 0
 	utcsplit := strings.Split(inOffset, ":")
 
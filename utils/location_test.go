@@ -60,6 +60,20 @@ func TestGetLocation(t *testing.T) {
 			},
 			want: time.FixedZone("UTC-8", -8*60*60),
 		},
+		{
+			name: "IANA zone name",
+			args: args{
+				"UTC",
+			},
+			want: time.UTC,
+		},
+		{
+			name: "Unknown zone name, return local timezone",
+			args: args{
+				"Nowhere/Unknown",
+			},
+			want: time.Local,
+		},
 	}
 	
 	for _, tt := range tests {
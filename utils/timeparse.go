@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateLayout layout of date without time.
+const DateLayout = "2006-01-02"
+
+// timeLayouts layouts of timestamps without offset.
+var timeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	DateLayout,
+}
+
+// ParseTime parse timestamp in RFC3339 or one of formats "2006-01-02T15:04[:05]", "2006-01-02 15:04[:05]", "2006-01-02".
+// Timestamp without offset interpreted in loc, or in IANA zone, given after space: "2026-11-03T22:00 Europe/Moscow".
+func ParseTime(value string, loc *time.Location) (time.Time, error) {
+	t, _, err := ParseTimeLayout(value, loc)
+	return t, err
+}
+
+// ParseTimeLayout parse timestamp as ParseTime, return also layout of parsed timestamp.
+func ParseTimeLayout(value string, loc *time.Location) (time.Time, string, error) {
+	value = strings.TrimSpace(value)
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, time.RFC3339, nil
+	}
+
+	if i := strings.LastIndex(value, " "); i > 0 {
+		if zone, err := time.LoadLocation(value[i+1:]); err == nil {
+			value, loc = strings.TrimSpace(value[:i]), zone
+		}
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, layout, nil
+		}
+	}
+
+	return time.Time{}, "", fmt.Errorf("parse time %q: unknown format", value)
+}
@@ -0,0 +1,58 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/utils"
+)
+
+func TestParseTime(t *testing.T) {
+	msk := time.FixedZone("UTC3", 3*60*60)
+
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:  "RFC3339",
+			value: "2026-11-03T22:00:00+03:00",
+			want:  time.Date(2026, 11, 3, 19, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "Without seconds in section location",
+			value: "2026-11-03T22:00",
+			want:  time.Date(2026, 11, 3, 22, 0, 0, 0, msk),
+		},
+		{
+			name:  "Date only",
+			value: "2026-11-03",
+			want:  time.Date(2026, 11, 3, 0, 0, 0, 0, msk),
+		},
+		{
+			name:  "Explicit zone",
+			value: "2026-11-04 04:00 UTC",
+			want:  time.Date(2026, 11, 4, 4, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Unknown format",
+			value:   "03.11.2026 22:00",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := utils.ParseTime(tt.value, msk)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("ParseTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
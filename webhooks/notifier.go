@@ -0,0 +1,200 @@
+// Package webhooks implements sending of silence lifecycle events to webhooks of sections.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"go.uber.org/zap"
+)
+
+const (
+	// SignatureHeader header with HMAC-SHA256 signature of body, "sha256=<hex>".
+	SignatureHeader = "X-Silences-Sheduler-Signature"
+	// EventHeader header with type of event.
+	EventHeader = "X-Silences-Sheduler-Event"
+
+	requestTimeout = 10 * time.Second
+)
+
+// Notifier send events to webhooks asynchronously, with retries.
+type Notifier struct {
+	retries int           // Count of retries after first failed attempt.
+	backoff time.Duration // Delay before first retry, doubled for every next retry.
+	client  *http.Client
+	logger  *zap.Logger
+	wg      sync.WaitGroup
+	ctx     context.Context // Context of requests and retries, cancelled by Shutdown after grace period.
+	cancel  context.CancelFunc
+	mux     sync.Mutex
+	closed  bool // Shutdown started, new events are dropped.
+}
+
+// NewNotifier return configured Notifier.
+func NewNotifier(retries int, backoff time.Duration, logger *zap.Logger) *Notifier {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Notifier{
+		retries: retries,
+		backoff: backoff,
+		client:  http.DefaultClient,
+		logger:  logger,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Notify send event to all webhooks accepting its type.
+func (o *Notifier) Notify(e models.Event) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		o.logger.Sugar().Errorf("Error marshal webhook event: %v", err)
+		return
+	}
+
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	if o.closed {
+		o.logger.Sugar().Warnf("Webhooks are shut down, %v event of silence %v dropped", e.Type, e.ID)
+		return
+	}
+
+	for _, target := range e.Targets {
+		if !target.Accept(e.Type) {
+			continue
+		}
+
+		o.wg.Add(1)
+
+		go func(target models.WebhookTarget) {
+			defer o.wg.Done()
+
+			if err := o.send(target, e.Type, body); err != nil {
+				o.logger.Sugar().Errorf("Error send %v event to webhook %v: %v", e.Type, target.URL, err)
+			}
+		}(target)
+	}
+}
+
+// Wait for sending of all events.
+func (o *Notifier) Wait() {
+	o.wg.Wait()
+}
+
+// Shutdown wait for sending of all events until ctx is done, then cancel requests and retries.
+// Events notified after start of Shutdown are dropped.
+func (o *Notifier) Shutdown(ctx context.Context) error {
+	defer o.cancel()
+
+	o.mux.Lock()
+	o.closed = true
+	o.mux.Unlock()
+
+	sent := make(chan struct{})
+
+	go func() {
+		o.wg.Wait()
+		close(sent)
+	}()
+
+	select {
+	case <-sent:
+		return nil
+	case <-ctx.Done():
+		o.cancel()
+		<-sent
+
+		return fmt.Errorf("webhooks: %w", ctx.Err())
+	}
+}
+
+// send body to webhook, retry on errors.
+func (o *Notifier) send(target models.WebhookTarget, eventType models.EventType, body []byte) error {
+	secret, err := secret(target)
+	if err != nil {
+		return err
+	}
+
+	backoff := o.backoff
+
+	for attempt := 0; ; attempt++ {
+		err = o.post(target.URL, secret, eventType, body)
+		if err == nil || attempt >= o.retries {
+			return err
+		}
+
+		o.logger.Sugar().Infof("Webhook %v attempt %v failed: %v, retry in %v", target.URL, attempt+1, err, backoff)
+
+		select {
+		case <-time.After(backoff):
+		case <-o.ctx.Done():
+			return err
+		}
+
+		backoff *= 2
+	}
+}
+
+func (o *Notifier) post(url, secret string, eventType models.EventType, body []byte) error {
+	ctx, cancel := context.WithTimeout(o.ctx, requestTimeout)
+	defer cancel()
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set(EventHeader, string(eventType))
+
+	if secret != "" {
+		r.Header.Set(SignatureHeader, "sha256="+Sign(secret, body))
+	}
+
+	res, err := o.client.Do(r)
+	if err != nil {
+		return err
+	}
+
+	res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("status %v", res.Status)
+	}
+
+	return nil
+}
+
+// Sign return hex encoded HMAC-SHA256 of body with secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// secret return secret of webhook, from config or file.
+func secret(target models.WebhookTarget) (string, error) {
+	if target.Secret != "" || target.SecretFile == "" {
+		return target.Secret, nil
+	}
+
+	data, err := os.ReadFile(target.SecretFile)
+	if err != nil {
+		return "", fmt.Errorf("read secret file: %w", err)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,140 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Volkov-Stanislav/silences-sheduler/models"
+	"github.com/Volkov-Stanislav/silences-sheduler/webhooks"
+	"go.uber.org/zap"
+)
+
+func TestNotifier_SignAndRetry(t *testing.T) {
+	var (
+		mux      sync.Mutex
+		attempts int
+		received models.Event
+		valid    bool
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.Lock()
+		defer mux.Unlock()
+
+		attempts++
+		if attempts < 3 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		valid = r.Header.Get(webhooks.SignatureHeader) == "sha256="+webhooks.Sign("s3cret", body) &&
+			r.Header.Get(webhooks.EventHeader) == "created"
+		_ = json.Unmarshal(body, &received)
+	}))
+	defer srv.Close()
+
+	notifier := webhooks.NewNotifier(3, time.Millisecond, zap.NewNop())
+	notifier.Notify(models.Event{
+		Type:    models.EventCreated,
+		Section: "backups.yaml",
+		ID:      "id-1",
+		Targets: []models.WebhookTarget{
+			{URL: srv.URL, Secret: "s3cret"},
+			{URL: srv.URL, Events: []models.EventType{models.EventExpired}},
+		},
+	})
+	notifier.Wait()
+
+	if attempts != 3 || !valid || received.ID != "id-1" || received.Section != "backups.yaml" {
+		t.Errorf("attempts = %v, valid signature = %v, event = %v", attempts, valid, received)
+	}
+}
+
+func TestNotifier_GiveUp(t *testing.T) {
+	var attempts int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	notifier := webhooks.NewNotifier(2, time.Millisecond, zap.NewNop())
+	notifier.Notify(models.Event{Type: models.EventFailed, Targets: []models.WebhookTarget{{URL: srv.URL}}})
+	notifier.Wait()
+
+	if attempts != 3 {
+		t.Errorf("attempts = %v, want 3", attempts)
+	}
+}
+
+func TestNotifier_Shutdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		delay   time.Duration
+		wantErr bool
+	}{
+		{name: "Events sent in grace period", delay: 10 * time.Millisecond},
+		{name: "Sending cancelled after grace period", delay: time.Minute, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(tt.delay):
+				case <-release:
+				}
+			}))
+			defer srv.Close()
+			defer close(release)
+
+			notifier := webhooks.NewNotifier(3, time.Minute, zap.NewNop())
+			notifier.Notify(models.Event{Type: models.EventCreated, Targets: []models.WebhookTarget{{URL: srv.URL}}})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			started := time.Now()
+
+			if err := notifier.Shutdown(ctx); (err != nil) != tt.wantErr {
+				t.Errorf("Shutdown() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if elapsed := time.Since(started); elapsed > 5*time.Second {
+				t.Errorf("Shutdown() took %v", elapsed)
+			}
+		})
+	}
+}
+
+func TestNotifier_NotifyAfterShutdown(t *testing.T) {
+	var requests int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer srv.Close()
+
+	notifier := webhooks.NewNotifier(0, time.Millisecond, zap.NewNop())
+	if err := notifier.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	notifier.Notify(models.Event{Type: models.EventExpired, Targets: []models.WebhookTarget{{URL: srv.URL}}})
+	notifier.Wait()
+
+	if got := atomic.LoadInt32(&requests); got != 0 {
+		t.Errorf("requests after Shutdown = %v, want 0", got)
+	}
+}